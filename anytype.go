@@ -0,0 +1,333 @@
+package protogetter
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+const (
+	anyTypeURLMsgFormat  = "avoid comparing %s with a type URL string, use %s instead"
+	anyUnknownMessageIs  = ".MessageIs with a message of this type"
+	anyMismatchMsgFormat = "%s target %s does not match the guard %s for %s"
+)
+
+// checkAnyTypes reports comparisons of `Any` type URLs with strings, like `a.GetTypeUrl() == "type.googleapis.com/foo.Bar"`,
+// instead of `a.MessageIs(&pb.Bar{})`, and `UnmarshalTo` targets or `UnmarshalNew` type assertions
+// that don't match the enclosing `MessageIs` guard.
+func checkAnyTypes(pass *analysis.Pass, ins *inspector.Inspector) {
+	resolver := newMessageResolver(pass, make(generatedFiles))
+	unmarshaled := collectUnmarshalNew(pass, ins)
+
+	nodeTypes := []ast.Node{
+		(*ast.BinaryExpr)(nil),
+		(*ast.SwitchStmt)(nil),
+		(*ast.CallExpr)(nil),
+		(*ast.TypeAssertExpr)(nil),
+	}
+
+	ins.WithStack(nodeTypes, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+
+		switch x := n.(type) {
+		case *ast.BinaryExpr:
+			if x.Op != token.EQL && x.Op != token.NEQ {
+				return true
+			}
+
+			for _, pair := range [][2]ast.Expr{{x.X, x.Y}, {x.Y, x.X}} {
+				owner, ok := typeURLRead(pass.TypesInfo, pair[0])
+				if !ok {
+					continue
+				}
+
+				url, ok := constantString(pass.TypesInfo, pair[1])
+				if !ok {
+					continue
+				}
+
+				reportTypeURLComparison(pass, resolver, x, pair[0], owner, url, x.Op == token.NEQ)
+				break
+			}
+
+		case *ast.SwitchStmt:
+			if x.Tag == nil {
+				return true
+			}
+
+			owner, ok := typeURLRead(pass.TypesInfo, x.Tag)
+			if !ok {
+				return true
+			}
+
+			for _, stmt := range x.Body.List {
+				for _, expr := range stmt.(*ast.CaseClause).List {
+					url, ok := constantString(pass.TypesInfo, expr)
+					if !ok {
+						continue
+					}
+
+					// The case can't be rewritten alone, so the fix is left to the switch author.
+					replacement := formatNode(owner) + anyUnknownMessageIs
+					if lit, ok := messageLiteral(pass, resolver, expr.Pos(), url); ok {
+						replacement = fmt.Sprintf("%s.MessageIs(%s)", formatNode(owner), lit)
+					}
+
+					pass.Report(analysis.Diagnostic{
+						Pos:      expr.Pos(),
+						End:      expr.End(),
+						Category: "any",
+						Message:  fmt.Sprintf(anyTypeURLMsgFormat, formatNode(x.Tag), replacement),
+					})
+				}
+			}
+
+		case *ast.CallExpr:
+			owner, target, ok := unmarshalToTarget(pass.TypesInfo, x)
+			if !ok {
+				return true
+			}
+
+			reportAnyMismatch(pass, stack, x, "UnmarshalTo", owner, pass.TypesInfo.TypeOf(target))
+
+		case *ast.TypeAssertExpr:
+			ident, ok := ast.Unparen(x.X).(*ast.Ident)
+			if !ok || x.Type == nil {
+				return true
+			}
+
+			owner, ok := unmarshaled[pass.TypesInfo.ObjectOf(ident)]
+			if !ok {
+				return true
+			}
+
+			reportAnyMismatch(pass, stack, x, "UnmarshalNew", owner, pass.TypesInfo.TypeOf(x.Type))
+		}
+
+		return true
+	})
+}
+
+// reportTypeURLComparison reports `a.GetTypeUrl() == url`, fixed with `a.MessageIs(&pb.X{})`
+// when the message of the url is known in the file.
+func reportTypeURLComparison(pass *analysis.Pass, resolver *messageResolver, expr *ast.BinaryExpr, read, owner ast.Expr, url string, negate bool) {
+	replacement := formatNode(owner) + anyUnknownMessageIs
+
+	// The fix needs a message to pass to MessageIs, there is none when it can't be named in the file.
+	lit, ok := messageLiteral(pass, resolver, expr.Pos(), url)
+	if ok {
+		replacement = fmt.Sprintf("%s.MessageIs(%s)", formatNode(owner), lit)
+		if negate {
+			replacement = "!" + replacement
+		}
+	}
+
+	diag := analysis.Diagnostic{
+		Pos:      expr.Pos(),
+		End:      expr.End(),
+		Category: "any",
+		Message:  fmt.Sprintf(anyTypeURLMsgFormat, formatNode(read), replacement),
+	}
+
+	if ok {
+		diag.SuggestedFixes = []analysis.SuggestedFix{
+			{
+				Message: "use " + replacement,
+				TextEdits: []analysis.TextEdit{
+					{
+						Pos:     expr.Pos(),
+						End:     expr.End(),
+						NewText: []byte(replacement),
+					},
+				},
+			},
+		}
+	}
+
+	pass.Report(diag)
+}
+
+// reportAnyMismatch reports the unmarshal target type that differs from the type checked
+// by the enclosing `MessageIs` guard of the same `Any`.
+func reportAnyMismatch(pass *analysis.Pass, stack []ast.Node, node ast.Node, method string, owner ast.Expr, target types.Type) {
+	if !isProtoMessagePointer(target) {
+		return
+	}
+
+	guard, guardType, ok := messageIsGuard(pass.TypesInfo, stack, accessPath(pass.TypesInfo, owner))
+	if !ok || types.Identical(guardType, target) {
+		return
+	}
+
+	file := fileOf(pass, node.Pos())
+	pass.Report(analysis.Diagnostic{
+		Pos:      node.Pos(),
+		End:      node.End(),
+		Category: "any",
+		Message: fmt.Sprintf(anyMismatchMsgFormat,
+			method, caseTypeString(pass.Pkg, file, target), formatNode(guard), caseTypeString(pass.Pkg, file, guardType)),
+		Related: []analysis.RelatedInformation{
+			{
+				Pos:     guard.Pos(),
+				End:     guard.End(),
+				Message: "the guard is here",
+			},
+		},
+	})
+}
+
+// collectUnmarshalNew finds the variables assigned from `a.UnmarshalNew()` or `anypb.UnmarshalNew(a, opts)`
+// and returns the `Any` each of them is unmarshaled from.
+func collectUnmarshalNew(pass *analysis.Pass, ins *inspector.Inspector) map[types.Object]ast.Expr {
+	vars := make(map[types.Object]ast.Expr)
+
+	ins.Preorder([]ast.Node{(*ast.AssignStmt)(nil)}, func(n ast.Node) {
+		assign := n.(*ast.AssignStmt)
+		if len(assign.Rhs) != 1 || len(assign.Lhs) == 0 {
+			return
+		}
+
+		call, ok := ast.Unparen(assign.Rhs[0]).(*ast.CallExpr)
+		if !ok {
+			return
+		}
+
+		var owner ast.Expr
+		if fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func); ok && fn.Name() == "UnmarshalNew" && fn.Pkg() != nil && fn.Pkg().Path() == anypbPkgPath {
+			if sel, ok := call.Fun.(*ast.SelectorExpr); ok && wellKnownType(pass.TypesInfo.TypeOf(sel.X)) == "anypb.Any" {
+				owner = sel.X
+			} else if len(call.Args) > 0 {
+				owner = call.Args[0]
+			}
+		}
+
+		if owner == nil {
+			return
+		}
+
+		ident, ok := assign.Lhs[0].(*ast.Ident)
+		if !ok {
+			return
+		}
+
+		if obj := pass.TypesInfo.ObjectOf(ident); obj != nil {
+			vars[obj] = owner
+		}
+	})
+
+	return vars
+}
+
+// unmarshalToTarget checks that the call is `a.UnmarshalTo(m)` or `anypb.UnmarshalTo(a, m, opts)`
+// and returns the `Any` and the target message.
+func unmarshalToTarget(info *types.Info, call *ast.CallExpr) (ast.Expr, ast.Expr, bool) {
+	fn, ok := typeutil.Callee(info, call).(*types.Func)
+	if !ok || fn.Name() != "UnmarshalTo" || fn.Pkg() == nil || fn.Pkg().Path() != anypbPkgPath {
+		return nil, nil, false
+	}
+
+	if sel, ok := call.Fun.(*ast.SelectorExpr); ok && wellKnownType(info.TypeOf(sel.X)) == "anypb.Any" {
+		if len(call.Args) != 1 {
+			return nil, nil, false
+		}
+		return sel.X, call.Args[0], true
+	}
+
+	if len(call.Args) < 2 {
+		return nil, nil, false
+	}
+
+	return call.Args[0], call.Args[1], true
+}
+
+// messageIsGuard returns the innermost `a.MessageIs(m)` of the path guarding the node:
+// the condition of an enclosing if, possibly joined with &&, or the single expression of a tagless switch case.
+func messageIsGuard(info *types.Info, stack []ast.Node, path string) (*ast.CallExpr, types.Type, bool) {
+	for i := len(stack) - 2; i >= 0; i-- {
+		var conds []ast.Expr
+		switch x := stack[i].(type) {
+		case *ast.IfStmt:
+			if stack[i+1] == x.Body {
+				conds = conjuncts(x.Cond)
+			}
+
+		case *ast.CaseClause:
+			if len(x.List) == 1 && i > 1 {
+				if sw, ok := stack[i-2].(*ast.SwitchStmt); ok && sw.Tag == nil {
+					conds = conjuncts(x.List[0])
+				}
+			}
+		}
+
+		for _, cond := range conds {
+			call, ok := ast.Unparen(cond).(*ast.CallExpr)
+			if !ok || len(call.Args) != 1 {
+				continue
+			}
+
+			sel, ok := call.Fun.(*ast.SelectorExpr)
+			if !ok || sel.Sel.Name != "MessageIs" || wellKnownType(info.TypeOf(sel.X)) != "anypb.Any" {
+				continue
+			}
+
+			t := info.TypeOf(call.Args[0])
+			if accessPath(info, sel.X) == path && isProtoMessagePointer(t) {
+				return call, t, true
+			}
+		}
+	}
+
+	return nil, nil, false
+}
+
+// conjuncts splits the && chain into its operands.
+func conjuncts(expr ast.Expr) []ast.Expr {
+	if x, ok := ast.Unparen(expr).(*ast.BinaryExpr); ok && x.Op == token.LAND {
+		return append(conjuncts(x.X), conjuncts(x.Y)...)
+	}
+
+	return []ast.Expr{expr}
+}
+
+// typeURLRead checks that the expression is `a.TypeUrl` or `a.GetTypeUrl()` and returns the `Any`.
+func typeURLRead(info *types.Info, expr ast.Expr) (ast.Expr, bool) {
+	return wellKnownFieldRead(info, expr, "anypb.Any", "TypeUrl")
+}
+
+func constantString(info *types.Info, expr ast.Expr) (string, bool) {
+	tv, ok := info.Types[expr]
+	if !ok || tv.Value == nil || tv.Value.Kind() != constant.String {
+		return "", false
+	}
+
+	return constant.StringVal(tv.Value), true
+}
+
+// messageLiteral returns the `&pb.X{}` literal of the message of the type URL, as written in the file of the position.
+func messageLiteral(pass *analysis.Pass, resolver *messageResolver, pos token.Pos, url string) (string, bool) {
+	obj, ok := resolver.lookup(url[strings.LastIndex(url, "/")+1:])
+	if !ok {
+		return "", false
+	}
+
+	file := fileOf(pass, pos)
+	if file == nil {
+		return "", false
+	}
+
+	name, ok := qualifiedTypeString(pass.Pkg, file, obj.Type())
+	if !ok {
+		return "", false
+	}
+
+	return "&" + name + "{}", true
+}
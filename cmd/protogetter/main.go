@@ -1,11 +1,17 @@
 package main
 
 import (
+	"os"
+
 	"golang.org/x/tools/go/analysis/singlechecker"
 
 	"github.com/ghostiam/protogetter"
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "migrate-opaque" {
+		os.Exit(migrateOpaque(os.Args[2:], os.Stdout, os.Stderr))
+	}
+
 	singlechecker.Main(protogetter.NewAnalyzer(nil))
 }
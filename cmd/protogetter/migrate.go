@@ -0,0 +1,266 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"go/format"
+	"go/token"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/checker"
+	"golang.org/x/tools/go/packages"
+
+	"github.com/ghostiam/protogetter"
+)
+
+const migrateOpaqueUsage = `usage: protogetter migrate-opaque [-n] [packages]
+
+Rewrites the code using hybrid API messages so it also compiles with the opaque API:
+composite literals become builders, writes become setters, nil checks become Has
+and reads become getters. The packages and their tests are migrated one by one,
+then the uses that could not be converted automatically are listed.
+`
+
+// migrateOpaque runs the migrate-opaque subcommand and returns the exit code.
+func migrateOpaque(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("migrate-opaque", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprint(stderr, migrateOpaqueUsage)
+		fs.PrintDefaults()
+	}
+	dryRun := fs.Bool("n", false, "count the fixes per file without writing the files")
+
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	patterns := fs.Args()
+	if len(patterns) == 0 {
+		patterns = []string{"./..."}
+	}
+
+	pkgs, err := packages.Load(&packages.Config{Mode: packages.LoadAllSyntax, Tests: true}, patterns...)
+	if err != nil {
+		fmt.Fprintf(stderr, "protogetter: %v\n", err)
+		return 1
+	}
+
+	pkgs = withoutTestDuplicates(pkgs)
+
+	sort.Slice(pkgs, func(i, j int) bool {
+		return pkgs[i].PkgPath < pkgs[j].PkgPath
+	})
+
+	analyzer := protogetter.NewAnalyzer(&protogetter.Config{MigrateOpaque: true})
+
+	var (
+		failed       bool
+		unconverted  []string
+		changedFiles int
+	)
+
+	for _, pkg := range pkgs {
+		if len(pkg.Errors) > 0 {
+			for _, e := range pkg.Errors {
+				fmt.Fprintf(stderr, "%s: %v\n", pkg.PkgPath, e)
+			}
+			failed = true
+			continue
+		}
+
+		graph, err := checker.Analyze([]*analysis.Analyzer{analyzer}, []*packages.Package{pkg}, nil)
+		if err != nil {
+			fmt.Fprintf(stderr, "%s: %v\n", pkg.PkgPath, err)
+			failed = true
+			continue
+		}
+
+		var diags []analysis.Diagnostic
+		for _, act := range graph.Roots {
+			if act.Err != nil {
+				fmt.Fprintf(stderr, "%s: %v\n", pkg.PkgPath, act.Err)
+				failed = true
+				continue
+			}
+			diags = append(diags, act.Diagnostics...)
+		}
+
+		fixes := make(map[string][][]analysis.TextEdit)
+		for _, diag := range diags {
+			if len(diag.SuggestedFixes) == 0 {
+				unconverted = append(unconverted, fmt.Sprintf("%s: %s", pkg.Fset.Position(diag.Pos), diag.Message))
+				continue
+			}
+
+			// The edits of a fix are grouped by file, a fix is applied to a file entirely or not at all.
+			byFile := make(map[string][]analysis.TextEdit)
+			for _, edit := range diag.SuggestedFixes[0].TextEdits {
+				name := pkg.Fset.File(edit.Pos).Name()
+				byFile[name] = append(byFile[name], edit)
+			}
+
+			for name, edits := range byFile {
+				fixes[name] = append(fixes[name], edits)
+			}
+		}
+
+		files := make([]string, 0, len(fixes))
+		for name := range fixes {
+			files = append(files, name)
+		}
+		sort.Strings(files)
+
+		for _, name := range files {
+			applied, err := applyFixes(pkg.Fset, name, fixes[name], *dryRun)
+			if err != nil {
+				fmt.Fprintf(stderr, "%s: %v\n", name, err)
+				failed = true
+				continue
+			}
+
+			fmt.Fprintf(stdout, "%s: %d fixes\n", name, applied)
+			if skipped := len(fixes[name]) - applied; skipped > 0 {
+				fmt.Fprintf(stdout, "%s: %d fixes conflict with another one, run the command again to apply them\n", name, skipped)
+			}
+			changedFiles++
+		}
+	}
+
+	summary := "migrated %d files in %d packages\n"
+	if *dryRun {
+		summary = "would migrate %d files in %d packages\n"
+	}
+	fmt.Fprintf(stdout, summary, changedFiles, len(pkgs))
+
+	if len(unconverted) > 0 {
+		fmt.Fprintf(stdout, "\n%d uses could not be converted automatically:\n", len(unconverted))
+		for _, s := range unconverted {
+			fmt.Fprintf(stdout, "\t%s\n", s)
+		}
+	}
+
+	if failed {
+		return 1
+	}
+
+	return 0
+}
+
+// withoutTestDuplicates drops the packages whose files are all in their test variant, like `p` for `p [p.test]`,
+// and the generated test main packages, so each file is migrated once.
+func withoutTestDuplicates(pkgs []*packages.Package) []*packages.Package {
+	ids := make(map[string]struct{}, len(pkgs))
+	for _, pkg := range pkgs {
+		ids[pkg.ID] = struct{}{}
+	}
+
+	result := make([]*packages.Package, 0, len(pkgs))
+	for _, pkg := range pkgs {
+		if pkg.Name == "main" && strings.HasSuffix(pkg.ID, ".test") {
+			continue
+		}
+
+		if _, ok := ids[pkg.ID+" ["+pkg.PkgPath+".test]"]; ok {
+			continue
+		}
+
+		result = append(result, pkg)
+	}
+
+	return result
+}
+
+// applyFixes applies the fixes to the file, formats it and returns the number of applied fixes.
+// Like the fixes applied by x/tools, a fix with an edit overlapping an edit of an already
+// accepted fix is dropped whole, so a fix is never half-applied. The edits repeated
+// by several fixes, like the same import, are applied once.
+func applyFixes(fset *token.FileSet, name string, fixes [][]analysis.TextEdit, dryRun bool) (int, error) {
+	src, err := os.ReadFile(name)
+	if err != nil {
+		return 0, err
+	}
+
+	tf := fset.File(fixes[0][0].Pos)
+
+	sort.SliceStable(fixes, func(i, j int) bool {
+		return fixes[i][0].Pos < fixes[j][0].Pos
+	})
+
+	var (
+		accepted []analysis.TextEdit
+		applied  int
+	)
+
+	for _, fix := range fixes {
+		var added []analysis.TextEdit
+		conflict := false
+
+	edits:
+		for _, edit := range fix {
+			for _, other := range append(accepted, added...) {
+				if edit.Pos == other.Pos && edit.End == other.End && string(edit.NewText) == string(other.NewText) {
+					continue edits
+				}
+
+				if editsOverlap(edit, other) {
+					conflict = true
+					break edits
+				}
+			}
+
+			added = append(added, edit)
+		}
+
+		if conflict {
+			continue
+		}
+
+		accepted = append(accepted, added...)
+		applied++
+	}
+
+	sort.SliceStable(accepted, func(i, j int) bool {
+		if accepted[i].Pos != accepted[j].Pos {
+			return accepted[i].Pos < accepted[j].Pos
+		}
+		return accepted[i].End < accepted[j].End
+	})
+
+	var (
+		out  []byte
+		last int
+	)
+
+	for _, edit := range accepted {
+		start, end := tf.Offset(edit.Pos), tf.Offset(edit.End)
+		out = append(out, src[last:start]...)
+		out = append(out, edit.NewText...)
+		last = end
+	}
+	out = append(out, src[last:]...)
+
+	formatted, err := format.Source(out)
+	if err != nil {
+		return 0, fmt.Errorf("format migrated code: %w", err)
+	}
+
+	if dryRun {
+		return applied, nil
+	}
+
+	return applied, os.WriteFile(name, formatted, 0o644)
+}
+
+// editsOverlap reports whether the edits change the same text, or insert different texts at the same position.
+func editsOverlap(a, b analysis.TextEdit) bool {
+	if a.Pos == a.End && b.Pos == b.End {
+		return a.Pos == b.Pos
+	}
+
+	return a.Pos < b.End && b.Pos < a.End
+}
@@ -0,0 +1,217 @@
+package main
+
+import (
+	"bytes"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+func TestApplyFixes(t *testing.T) {
+	const src = `package p
+
+func f(h *T) {
+	h.Tags = append(h.Tags, "t")
+}
+`
+
+	name := filepath.Join(t.TempDir(), "p.go")
+	if err := os.WriteFile(name, []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	fset := token.NewFileSet()
+	if _, err := parser.ParseFile(fset, name, src, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	var tf *token.File
+	fset.Iterate(func(f *token.File) bool {
+		tf = f
+		return false
+	})
+
+	pos := func(s string) token.Pos {
+		return tf.Pos(strings.Index(src, s))
+	}
+	edit := func(from, to, text string) analysis.TextEdit {
+		return analysis.TextEdit{Pos: pos(from), End: pos(from) + token.Pos(len(to)), NewText: []byte(text)}
+	}
+
+	setTags := []analysis.TextEdit{
+		edit("h.Tags = ", "h.Tags = ", "h.SetTags("),
+		edit("\n}", "", ")"),
+	}
+	fixes := [][]analysis.TextEdit{
+		setTags,
+		// The same fix reported twice is applied once.
+		setTags,
+		// The getter fix overlaps the first edit of the setter fix, it is dropped whole.
+		{edit("h.Tags = ", "h.Tags", "h.GetTags()"), edit("func", "", "// getter\n")},
+		{edit("h.Tags, ", "h.Tags", "h.GetTags()")},
+	}
+
+	applied, err := applyFixes(fset, name, fixes, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if applied != 3 {
+		t.Errorf("applied %d fixes, want 3", applied)
+	}
+
+	got, err := os.ReadFile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `package p
+
+func f(h *T) {
+	h.SetTags(append(h.GetTags(), "t"))
+}
+`
+	if string(got) != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+// migrateDir copies the migrateopaque testdata into a new package of the testdata module,
+// since the migrated package has to be in the module to import the hybrid messages.
+func migrateDir(t *testing.T) (testdata, dir string) {
+	t.Helper()
+
+	testdata, err := filepath.Abs(filepath.Join("..", "..", "testdata"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir, err = os.MkdirTemp(testdata, "migrate")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	src, err := os.ReadFile(filepath.Join(testdata, "migrateopaque", "migrateopaque.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "migrateopaque.go"), src, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	return testdata, dir
+}
+
+func TestMigrateOpaqueDryRun(t *testing.T) {
+	testdata, dir := migrateDir(t)
+
+	src, err := os.ReadFile(filepath.Join(dir, "migrateopaque.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Chdir(testdata)
+
+	var stdout, stderr bytes.Buffer
+	if code := migrateOpaque([]string{"-n", "./" + filepath.Base(dir)}, &stdout, &stderr); code != 0 {
+		t.Fatalf("exit code %d, stderr:\n%s", code, stderr.String())
+	}
+
+	got, err := os.ReadFile(filepath.Join(dir, "migrateopaque.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(got) != string(src) {
+		t.Errorf("the file is written in the dry run:\n%s", got)
+	}
+
+	if !strings.Contains(stdout.String(), "would migrate 1 files in 1 packages") {
+		t.Errorf("the summary does not say the files are not written:\n%s", stdout.String())
+	}
+}
+
+func TestMigrateOpaque(t *testing.T) {
+	testdata, dir := migrateDir(t)
+
+	const testSrc = `package migrateopaque
+
+import (
+	"testing"
+
+	"github.com/ghostiam/protogetter/testdata/proto"
+)
+
+func TestHybrid(t *testing.T) {
+	h := &proto.TestHybrid{Implicit: "a"}
+	h.Implicit = "implicit"
+}
+`
+	if err := os.WriteFile(filepath.Join(dir, "migrateopaque_test.go"), []byte(testSrc), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Chdir(testdata)
+
+	var stdout, stderr bytes.Buffer
+	if code := migrateOpaque([]string{"./" + filepath.Base(dir)}, &stdout, &stderr); code != 0 {
+		t.Fatalf("exit code %d, stderr:\n%s", code, stderr.String())
+	}
+
+	golden, err := os.ReadFile(filepath.Join(testdata, "migrateopaque", "migrateopaque.go.golden"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want, err := format.Source(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := os.ReadFile(filepath.Join(dir, "migrateopaque.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(got) != string(want) {
+		t.Errorf("migrateopaque.go:\n%s\nwant:\n%s", got, want)
+	}
+
+	gotTest, err := os.ReadFile(filepath.Join(dir, "migrateopaque_test.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantTest := `package migrateopaque
+
+import (
+	"testing"
+
+	"github.com/ghostiam/protogetter/testdata/proto"
+)
+
+func TestHybrid(t *testing.T) {
+	h := proto.TestHybrid_builder{Implicit: "a"}.Build()
+	h.SetImplicit("implicit")
+}
+`
+	if string(gotTest) != wantTest {
+		t.Errorf("migrateopaque_test.go:\n%s\nwant:\n%s", gotTest, wantTest)
+	}
+
+	if !strings.Contains(stdout.String(), "migrateopaque_test.go: 2 fixes") {
+		t.Errorf("the test file is not in the summary:\n%s", stdout.String())
+	}
+
+	if !strings.Contains(stdout.String(), "uses could not be converted automatically") {
+		t.Errorf("the unconverted uses are not in the summary:\n%s", stdout.String())
+	}
+}
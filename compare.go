@@ -0,0 +1,250 @@
+package protogetter
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+const (
+	compareMsgFormat = "avoid comparing proto messages with %s, use %s instead"
+
+	protoPkgPath    = "google.golang.org/protobuf/proto"
+	protocmpPkgPath = "google.golang.org/protobuf/testing/protocmp"
+)
+
+func checkComparisons(pass *analysis.Pass, ins *inspector.Inspector) {
+	nodeTypes := []ast.Node{
+		(*ast.BinaryExpr)(nil),
+		(*ast.CallExpr)(nil),
+	}
+
+	ins.Preorder(nodeTypes, func(n ast.Node) {
+		switch x := n.(type) {
+		case *ast.BinaryExpr:
+			checkBinaryComparison(pass, x)
+		case *ast.CallExpr:
+			checkCallComparison(pass, x)
+		}
+	})
+}
+
+// checkBinaryComparison reports `a == b` of message pointers and `a.String() == b.String()`.
+func checkBinaryComparison(pass *analysis.Pass, expr *ast.BinaryExpr) {
+	if expr.Op != token.EQL && expr.Op != token.NEQ {
+		return
+	}
+
+	x, y := expr.X, expr.Y
+	what := expr.Op.String()
+
+	if isProtoMessagePointer(pass.TypesInfo.TypeOf(x)) && isProtoMessagePointer(pass.TypesInfo.TypeOf(y)) {
+		if isNilIdent(x) || isNilIdent(y) {
+			return
+		}
+	} else {
+		var ok bool
+		x, ok = messageStringReceiver(pass.TypesInfo, x)
+		if !ok {
+			return
+		}
+		y, ok = messageStringReceiver(pass.TypesInfo, y)
+		if !ok {
+			return
+		}
+		what = "String()"
+	}
+
+	reportProtoEqual(pass, expr, what, expr.Op == token.NEQ, x, y, nil)
+}
+
+// messageStringReceiver checks that the expression is `m.String()` of a proto message and returns m.
+func messageStringReceiver(info *types.Info, expr ast.Expr) (ast.Expr, bool) {
+	call, ok := ast.Unparen(expr).(*ast.CallExpr)
+	if !ok || len(call.Args) != 0 {
+		return nil, false
+	}
+
+	fun, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || fun.Sel.Name != "String" || !isProtoMessage(info, fun.X) {
+		return nil, false
+	}
+
+	return fun.X, true
+}
+
+func checkCallComparison(pass *analysis.Pass, call *ast.CallExpr) {
+	fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+	if !ok || fn.Pkg() == nil {
+		return
+	}
+
+	switch fn.Pkg().Path() {
+	case "reflect":
+		if fn.Name() != "DeepEqual" || len(call.Args) != 2 || !anyProtoMessage(pass.TypesInfo, call.Args...) {
+			return
+		}
+
+		reportProtoEqual(pass, call, "reflect.DeepEqual", false, call.Args[0], call.Args[1], nil)
+
+	case "github.com/stretchr/testify/assert", "github.com/stretchr/testify/require":
+		var negate bool
+		switch fn.Name() {
+		case "Equal", "EqualValues", "Exactly":
+		case "NotEqual", "NotEqualValues":
+			negate = true
+		default:
+			return
+		}
+
+		if len(call.Args) < 3 || !anyProtoMessage(pass.TypesInfo, call.Args[1], call.Args[2]) {
+			return
+		}
+
+		reportProtoEqual(pass, call, fn.Pkg().Name()+"."+fn.Name(), negate, call.Args[1], call.Args[2], call)
+
+	case "github.com/google/go-cmp/cmp":
+		if fn.Name() != "Diff" && fn.Name() != "Equal" {
+			return
+		}
+
+		if len(call.Args) < 2 || !anyProtoMessage(pass.TypesInfo, call.Args[0], call.Args[1]) {
+			return
+		}
+
+		for _, opt := range call.Args[2:] {
+			if isProtocmpTransform(pass.TypesInfo, opt) {
+				return
+			}
+		}
+
+		reportMissingTransform(pass, call, "cmp."+fn.Name())
+	}
+}
+
+func anyProtoMessage(info *types.Info, exprs ...ast.Expr) bool {
+	for _, expr := range exprs {
+		if isProtoMessageType(info.TypeOf(expr)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isProtocmpTransform(info *types.Info, expr ast.Expr) bool {
+	call, ok := ast.Unparen(expr).(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+
+	fn, ok := typeutil.Callee(info, call).(*types.Func)
+	return ok && fn.Pkg() != nil && fn.Pkg().Path() == protocmpPkgPath && fn.Name() == "Transform"
+}
+
+// isV2MessagePointer reports whether the type is a pointer to a message implementing the v2 API,
+// which proto.Equal and the other google.golang.org/protobuf functions accept, unlike the v1-only messages.
+func isV2MessagePointer(t types.Type) bool {
+	named, _ := namedType(t)
+	return isProtoMessagePointer(t) && namedHasMethod(named, "ProtoReflect")
+}
+
+// reportProtoEqual reports the comparison and suggests proto.Equal(x, y).
+// For testify assertions, the fix keeps the assertion: `assert.True(t, proto.Equal(x, y), msgAndArgs...)`.
+func reportProtoEqual(pass *analysis.Pass, node ast.Node, what string, negate bool, x, y ast.Expr, assertion *ast.CallExpr) {
+	msg := fmt.Sprintf(compareMsgFormat, what, "proto.Equal")
+	diag := analysis.Diagnostic{
+		Pos:      node.Pos(),
+		End:      node.End(),
+		Category: "compare",
+		Message:  msg,
+	}
+
+	file := fileOf(pass, node.Pos())
+	canFix := file != nil &&
+		isV2MessagePointer(pass.TypesInfo.TypeOf(x)) &&
+		isV2MessagePointer(pass.TypesInfo.TypeOf(y)) &&
+		(assertion == nil || !assertion.Ellipsis.IsValid())
+	if !canFix {
+		pass.Report(diag)
+		return
+	}
+
+	name, edits := addImport(file, protoPkgPath)
+	equal := fmt.Sprintf("%s.Equal(%s, %s)", name, formatNode(x), formatNode(y))
+
+	var text string
+	switch {
+	case assertion != nil:
+		fun := "True"
+		if negate {
+			fun = "False"
+		}
+
+		args := []string{formatNode(assertion.Args[0]), equal}
+		for _, arg := range assertion.Args[3:] {
+			args = append(args, formatNode(arg))
+		}
+
+		sel := assertion.Fun.(*ast.SelectorExpr)
+		text = fmt.Sprintf("%s.%s(%s)", formatNode(sel.X), fun, strings.Join(args, ", "))
+
+	case negate:
+		text = "!" + equal
+
+	default:
+		text = equal
+	}
+
+	diag.SuggestedFixes = []analysis.SuggestedFix{
+		{
+			Message: msg,
+			TextEdits: append([]analysis.TextEdit{
+				{
+					Pos:     node.Pos(),
+					End:     node.End(),
+					NewText: []byte(text),
+				},
+			}, edits...),
+		},
+	}
+	pass.Report(diag)
+}
+
+// reportMissingTransform reports cmp.Diff and cmp.Equal of proto messages without protocmp.Transform().
+func reportMissingTransform(pass *analysis.Pass, call *ast.CallExpr, what string) {
+	msg := fmt.Sprintf(compareMsgFormat, what, what+" with protocmp.Transform()")
+	diag := analysis.Diagnostic{
+		Pos:      call.Pos(),
+		End:      call.End(),
+		Category: "compare",
+		Message:  msg,
+	}
+
+	file := fileOf(pass, call.Pos())
+	if file == nil || call.Ellipsis.IsValid() {
+		pass.Report(diag)
+		return
+	}
+
+	name, edits := addImport(file, protocmpPkgPath)
+	diag.SuggestedFixes = []analysis.SuggestedFix{
+		{
+			Message: msg,
+			TextEdits: append([]analysis.TextEdit{
+				{
+					Pos:     call.Args[len(call.Args)-1].End(),
+					End:     call.Args[len(call.Args)-1].End(),
+					NewText: []byte(", " + name + ".Transform()"),
+				},
+			}, edits...),
+		},
+	}
+	pass.Report(diag)
+}
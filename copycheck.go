@@ -0,0 +1,290 @@
+package protogetter
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+const (
+	copyValueMsgFormat    = "avoid copying proto message %s by value, use a pointer instead"
+	copyReceiverMsgFormat = "avoid value receiver of proto message %s, use a pointer receiver instead"
+	copyParamMsgFormat    = "avoid passing proto message %s by value, use a pointer instead"
+	copyResultMsgFormat   = "avoid returning proto message %s by value, use a pointer instead"
+	copyRangeMsgFormat    = "avoid copying proto messages by value when ranging over %s, use pointers instead"
+	copyElemMsgFormat     = "avoid storing proto messages by value in %s, use pointers instead"
+)
+
+// checkCopies reports copies of proto message structs. Messages contain internal state
+// (state, sizeCache, unknownFields) that must not be copied.
+func checkCopies(pass *analysis.Pass, ins *inspector.Inspector) {
+	nodeTypes := []ast.Node{
+		(*ast.FuncDecl)(nil),
+		(*ast.FuncLit)(nil),
+		(*ast.RangeStmt)(nil),
+		(*ast.ArrayType)(nil),
+		(*ast.MapType)(nil),
+		(*ast.ChanType)(nil),
+		(*ast.AssignStmt)(nil),
+		(*ast.ValueSpec)(nil),
+		(*ast.ReturnStmt)(nil),
+		(*ast.CallExpr)(nil),
+		(*ast.CompositeLit)(nil),
+		(*ast.SendStmt)(nil),
+	}
+
+	ins.Preorder(nodeTypes, func(n ast.Node) {
+		switch x := n.(type) {
+		case *ast.FuncDecl:
+			if x.Recv != nil {
+				for _, field := range x.Recv.List {
+					reportValueReceiver(pass, field)
+				}
+			}
+			reportValueParams(pass, x.Type)
+
+		case *ast.FuncLit:
+			reportValueParams(pass, x.Type)
+
+		case *ast.RangeStmt:
+			if x.Value != nil && isProtoMessageValue(pass.TypesInfo.TypeOf(x.Value)) {
+				reportCopy(pass, x.Value, fmt.Sprintf(copyRangeMsgFormat, formatNode(x.X)))
+			}
+
+		case *ast.ArrayType:
+			reportValueElem(pass, x, x.Elt)
+
+		case *ast.MapType:
+			reportValueElem(pass, x, x.Value)
+
+		case *ast.ChanType:
+			reportValueElem(pass, x, x.Value)
+
+		case *ast.AssignStmt:
+			for i, rhs := range x.Rhs {
+				if len(x.Lhs) != len(x.Rhs) || !isCopiedValue(pass.TypesInfo, rhs) {
+					continue
+				}
+
+				diag := copyValueDiagnostic(rhs)
+				if x.Tok == token.DEFINE {
+					if star, ok := rhs.(*ast.StarExpr); ok {
+						// `v := *m` is fixed to `v := proto.Clone(m).(*pb.Msg)`.
+						if fix, ok := cloneFix(pass, star, x.Lhs[i]); ok {
+							diag.SuggestedFixes = []analysis.SuggestedFix{fix}
+						}
+					}
+				}
+				pass.Report(diag)
+			}
+
+		case *ast.ValueSpec:
+			for i, value := range x.Values {
+				if !isCopiedValue(pass.TypesInfo, value) {
+					continue
+				}
+
+				diag := copyValueDiagnostic(value)
+				star, ok := value.(*ast.StarExpr)
+				if !ok || len(x.Names) != len(x.Values) {
+					pass.Report(diag)
+					continue
+				}
+
+				fix, ok := cloneFix(pass, star, x.Names[i])
+				switch {
+				case !ok:
+				case x.Type == nil:
+					// `var v = *m` is fixed to `var v = proto.Clone(m).(*pb.Msg)`.
+					diag.SuggestedFixes = []analysis.SuggestedFix{fix}
+				case len(x.Names) == 1:
+					// `var v pb.Msg = *m` is fixed to `var v *pb.Msg = proto.Clone(m).(*pb.Msg)`.
+					fix.TextEdits = append(fix.TextEdits, analysis.TextEdit{
+						Pos:     x.Type.Pos(),
+						End:     x.Type.Pos(),
+						NewText: []byte("*"),
+					})
+					diag.SuggestedFixes = []analysis.SuggestedFix{fix}
+				}
+				pass.Report(diag)
+			}
+
+		case *ast.ReturnStmt:
+			for _, result := range x.Results {
+				if isCopiedValue(pass.TypesInfo, result) {
+					pass.Report(copyValueDiagnostic(result))
+				}
+			}
+
+		case *ast.CallExpr:
+			if tv, ok := pass.TypesInfo.Types[x.Fun]; ok && tv.IsType() {
+				// Conversions are checked by their context.
+				return
+			}
+
+			for _, arg := range x.Args {
+				if isCopiedValue(pass.TypesInfo, arg) {
+					pass.Report(copyValueDiagnostic(arg))
+				}
+			}
+
+		case *ast.CompositeLit:
+			for _, elt := range x.Elts {
+				if kv, ok := elt.(*ast.KeyValueExpr); ok {
+					elt = kv.Value
+				}
+
+				if isCopiedValue(pass.TypesInfo, elt) {
+					pass.Report(copyValueDiagnostic(elt))
+				}
+			}
+
+		case *ast.SendStmt:
+			if isCopiedValue(pass.TypesInfo, x.Value) {
+				pass.Report(copyValueDiagnostic(x.Value))
+			}
+		}
+	})
+}
+
+// isProtoMessageValue reports whether the type is a proto message struct, not a pointer to it.
+func isProtoMessageValue(t types.Type) bool {
+	if t == nil {
+		return false
+	}
+
+	if _, ok := t.Underlying().(*types.Struct); !ok {
+		return false
+	}
+
+	return isProtoMessageType(t)
+}
+
+// isCopiedValue reports whether using the expression as a value copies a proto message.
+// Composite literals and call results create a new value, so they are not reported here.
+func isCopiedValue(info *types.Info, expr ast.Expr) bool {
+	tv, ok := info.Types[expr]
+	if !ok || !tv.IsValue() || !isProtoMessageValue(tv.Type) {
+		return false
+	}
+
+	switch ast.Unparen(expr).(type) {
+	case *ast.CompositeLit, *ast.CallExpr:
+		return false
+	}
+
+	return true
+}
+
+func copyValueDiagnostic(expr ast.Expr) analysis.Diagnostic {
+	return analysis.Diagnostic{
+		Pos:      expr.Pos(),
+		End:      expr.End(),
+		Category: "copy",
+		Message:  fmt.Sprintf(copyValueMsgFormat, formatNode(expr)),
+	}
+}
+
+// cloneFix replaces the dereference with proto.Clone, so the variable holds a pointer to a copy.
+// Removing the dereference would make the variable an alias of the message, so its writes would change
+// the original message. proto.Clone accepts only v2 messages.
+func cloneFix(pass *analysis.Pass, star *ast.StarExpr, name ast.Expr) (analysis.SuggestedFix, bool) {
+	t := pass.TypesInfo.TypeOf(star.X)
+	file := fileOf(pass, star.Pos())
+	if file == nil || !isV2MessagePointer(t) {
+		return analysis.SuggestedFix{}, false
+	}
+
+	typ, ok := qualifiedTypeString(pass.Pkg, file, t)
+	if !ok {
+		return analysis.SuggestedFix{}, false
+	}
+
+	protoName, edits := addImport(file, protoPkgPath)
+	return analysis.SuggestedFix{
+		Message: fmt.Sprintf("clone %s into %s", formatNode(star.X), formatNode(name)),
+		TextEdits: append([]analysis.TextEdit{
+			{
+				Pos:     star.Pos(),
+				End:     star.X.Pos(),
+				NewText: []byte(protoName + ".Clone("),
+			},
+			{
+				Pos:     star.End(),
+				End:     star.End(),
+				NewText: []byte(").(" + typ + ")"),
+			},
+		}, edits...),
+	}, true
+}
+
+func reportCopy(pass *analysis.Pass, node ast.Node, msg string) {
+	pass.Report(analysis.Diagnostic{
+		Pos:      node.Pos(),
+		End:      node.End(),
+		Category: "copy",
+		Message:  msg,
+	})
+}
+
+func reportValueReceiver(pass *analysis.Pass, field *ast.Field) {
+	if !isProtoMessageValue(pass.TypesInfo.TypeOf(field.Type)) {
+		return
+	}
+
+	msg := fmt.Sprintf(copyReceiverMsgFormat, formatNode(field.Type))
+	pass.Report(analysis.Diagnostic{
+		Pos:      field.Type.Pos(),
+		End:      field.Type.End(),
+		Category: "copy",
+		Message:  msg,
+		SuggestedFixes: []analysis.SuggestedFix{
+			{
+				Message: msg,
+				TextEdits: []analysis.TextEdit{
+					{
+						Pos:     field.Type.Pos(),
+						End:     field.Type.Pos(),
+						NewText: []byte("*"),
+					},
+				},
+			},
+		},
+	})
+}
+
+func reportValueParams(pass *analysis.Pass, fn *ast.FuncType) {
+	for _, list := range []struct {
+		fields *ast.FieldList
+		format string
+	}{
+		{fn.Params, copyParamMsgFormat},
+		{fn.Results, copyResultMsgFormat},
+	} {
+		if list.fields == nil {
+			continue
+		}
+
+		for _, field := range list.fields.List {
+			if isProtoMessageValue(pass.TypesInfo.TypeOf(field.Type)) {
+				reportCopy(pass, field.Type, fmt.Sprintf(list.format, formatNode(field.Type)))
+			}
+		}
+	}
+}
+
+func reportValueElem(pass *analysis.Pass, typ, elem ast.Expr) {
+	if tv, ok := pass.TypesInfo.Types[typ]; !ok || !tv.IsType() {
+		return
+	}
+
+	if !isProtoMessageValue(pass.TypesInfo.TypeOf(elem)) {
+		return
+	}
+
+	reportCopy(pass, typ, fmt.Sprintf(copyElemMsgFormat, formatNode(typ)))
+}
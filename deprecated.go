@@ -0,0 +1,256 @@
+package protogetter
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"regexp"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+const (
+	deprecatedMsgFormat        = "proto %s %s is deprecated, marked as deprecated in %s"
+	deprecatedMissingMsgFormat = "generated source %s of package %s is not available, its deprecated fields and enum values are not checked"
+)
+
+// deprecatedCommentRe matches the comment protoc-gen-go generates for the deprecated fields and enum values.
+var deprecatedCommentRe = regexp.MustCompile(`Deprecated: Marked as deprecated in (\S+\.proto)\.`)
+
+// accessorPrefixes are the prefixes of the generated methods accessing a field.
+var accessorPrefixes = []string{"Get", "Set", "Has", "Clear"}
+
+// checkDeprecated reports uses of the deprecated proto fields, their accessors and enum values.
+// A field is deprecated if its generated comment says so, or the descriptor embedded into
+// the generated file has the `deprecated` option set. When the generated file can't be read,
+// its first use is reported instead.
+func checkDeprecated(pass *analysis.Pass, ins *inspector.Inspector) {
+	nodeTypes := []ast.Node{
+		(*ast.Ident)(nil),
+	}
+
+	files := make(generatedFiles)
+	reported := make(map[string]struct{})
+	ins.Preorder(nodeTypes, func(n ast.Node) {
+		ident := n.(*ast.Ident)
+
+		obj := pass.TypesInfo.Uses[ident]
+		if obj == nil || obj.Pkg() == nil {
+			return
+		}
+
+		// Only the first use of a missing file is reported.
+		if filename, ok := files.missing(pass.Fset, obj); ok {
+			if _, ok := reported[filename]; !ok {
+				reported[filename] = struct{}{}
+				pass.Report(analysis.Diagnostic{
+					Pos:      ident.Pos(),
+					End:      ident.End(),
+					Category: "deprecated",
+					Message:  fmt.Sprintf(deprecatedMissingMsgFormat, filename, obj.Pkg().Path()),
+				})
+			}
+			return
+		}
+
+		var kind, name, protoFile string
+		var ok bool
+		switch obj := obj.(type) {
+		case *types.Var:
+			if !obj.IsField() {
+				return
+			}
+			kind = "field"
+			name, protoFile, ok = deprecatedField(pass, files, obj)
+
+		case *types.Func:
+			kind = "field"
+			name, protoFile, ok = deprecatedAccessor(pass, files, obj)
+
+		case *types.Const:
+			kind = "enum value"
+			name, protoFile, ok = deprecatedEnumValue(pass, files, obj)
+		}
+
+		if !ok {
+			return
+		}
+
+		pass.Report(analysis.Diagnostic{
+			Pos:      ident.Pos(),
+			End:      ident.End(),
+			Category: "deprecated",
+			Message:  fmt.Sprintf(deprecatedMsgFormat, kind, name, protoFile),
+		})
+	})
+}
+
+// deprecatedField checks the struct field of the generated message.
+func deprecatedField(pass *analysis.Pass, files generatedFiles, obj *types.Var) (name, protoFile string, ok bool) {
+	f := files.of(pass.Fset, obj)
+	if f == nil {
+		return "", "", false
+	}
+
+	path := f.path(pass.Fset, obj)
+	if len(path) < 5 {
+		return "", "", false
+	}
+
+	field, ok := path[1].(*ast.Field)
+	if !ok {
+		return "", "", false
+	}
+
+	spec, ok := path[4].(*ast.TypeSpec)
+	if !ok {
+		return "", "", false
+	}
+
+	var tag string
+	if field.Tag != nil {
+		tag = strings.Trim(field.Tag.Value, "`")
+	}
+
+	return deprecatedMessageField(f, spec.Name.Name, obj.Name(), tag, field.Doc, field.Comment)
+}
+
+// deprecatedAccessor checks the Get, Set, Has and Clear methods of the generated message.
+func deprecatedAccessor(pass *analysis.Pass, files generatedFiles, obj *types.Func) (name, protoFile string, ok bool) {
+	recv := obj.Signature().Recv()
+	if recv == nil {
+		return "", "", false
+	}
+
+	named, ok := namedType(recv.Type())
+	if !ok {
+		return "", "", false
+	}
+
+	st, ok := named.Underlying().(*types.Struct)
+	if !ok {
+		return "", "", false
+	}
+
+	var fieldName string
+	for _, prefix := range accessorPrefixes {
+		if s, ok := strings.CutPrefix(obj.Name(), prefix); ok && s != "" {
+			fieldName = s
+			break
+		}
+	}
+	if fieldName == "" {
+		return "", "", false
+	}
+
+	var tag string
+	for i := 0; i < st.NumFields(); i++ {
+		// The opaque API hides the fields behind the `xxx_hidden_` prefix.
+		if name := st.Field(i).Name(); name == fieldName || name == "xxx_hidden_"+fieldName {
+			tag = st.Tag(i)
+			break
+		}
+	}
+
+	f := files.of(pass.Fset, obj)
+	if f == nil {
+		return "", "", false
+	}
+
+	path := f.path(pass.Fset, obj)
+	if len(path) < 2 {
+		return "", "", false
+	}
+
+	decl, ok := path[1].(*ast.FuncDecl)
+	if !ok {
+		return "", "", false
+	}
+
+	return deprecatedMessageField(f, named.Obj().Name(), fieldName, tag, decl.Doc)
+}
+
+func deprecatedMessageField(f *generatedFile, msgName, fieldName, tag string, comments ...*ast.CommentGroup) (name, protoFile string, ok bool) {
+	name = msgName + "." + fieldName
+	protoFile, ok = deprecatedComment(comments...)
+
+	msg := f.message(msgName)
+	protoName, hasTag := fieldProtoName(tag)
+	if msg == nil || !hasTag {
+		return name, protoFile, ok
+	}
+
+	for _, field := range msg.GetField() {
+		if field.GetName() != protoName {
+			continue
+		}
+
+		name = msg.GetName() + "." + field.GetName()
+		if field.GetOptions().GetDeprecated() {
+			return name, f.desc.GetName(), true
+		}
+	}
+
+	return name, protoFile, ok
+}
+
+// deprecatedEnumValue checks the constant of the generated enum.
+func deprecatedEnumValue(pass *analysis.Pass, files generatedFiles, obj *types.Const) (name, protoFile string, ok bool) {
+	enum, ok := isProtoEnum(obj.Type())
+	if !ok {
+		return "", "", false
+	}
+
+	f := files.of(pass.Fset, obj)
+	if f == nil {
+		return "", "", false
+	}
+
+	path := f.path(pass.Fset, obj)
+	if len(path) < 2 {
+		return "", "", false
+	}
+
+	spec, ok := path[1].(*ast.ValueSpec)
+	if !ok {
+		return "", "", false
+	}
+
+	name = obj.Name()
+	protoFile, ok = deprecatedComment(spec.Doc, spec.Comment)
+
+	desc := f.enum(enum.Obj().Name())
+	if desc == nil {
+		return name, protoFile, ok
+	}
+
+	for _, value := range desc.GetValue() {
+		if obj.Name() != value.GetName() && !strings.HasSuffix(obj.Name(), "_"+value.GetName()) {
+			continue
+		}
+
+		name = desc.GetName() + "." + value.GetName()
+		if value.GetOptions().GetDeprecated() {
+			return name, f.desc.GetName(), true
+		}
+	}
+
+	return name, protoFile, ok
+}
+
+// deprecatedComment returns the .proto file from the `Deprecated: Marked as deprecated in foo.proto.` comment.
+func deprecatedComment(comments ...*ast.CommentGroup) (string, bool) {
+	for _, cg := range comments {
+		if cg == nil {
+			continue
+		}
+
+		if m := deprecatedCommentRe.FindStringSubmatch(cg.Text()); m != nil {
+			return m[1], true
+		}
+	}
+
+	return "", false
+}
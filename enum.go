@@ -0,0 +1,341 @@
+package protogetter
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+const (
+	enumSwitchMsgFormat     = "switch on proto enum %s is missing cases: %s"
+	enumConversionMsgFormat = "unchecked conversion of %s to proto enum %s, check the value with %s_name first"
+	enumUnknownMsgFormat    = "conversion of unknown value %s to proto enum %s"
+	enumLiteralMsgFormat    = "avoid comparing proto enum %s with literal %s, use %s instead"
+	enumLiteralNoConstMsg   = "avoid comparing proto enum %s with literal %s, it is not a known value"
+)
+
+func checkEnums(pass *analysis.Pass, ins *inspector.Inspector) {
+	nodeTypes := []ast.Node{
+		(*ast.SwitchStmt)(nil),
+		(*ast.CallExpr)(nil),
+		(*ast.BinaryExpr)(nil),
+	}
+
+	ins.WithStack(nodeTypes, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+
+		switch x := n.(type) {
+		case *ast.SwitchStmt:
+			checkEnumSwitch(pass, x)
+		case *ast.CallExpr:
+			checkEnumConversion(pass, x, stack)
+		case *ast.BinaryExpr:
+			checkEnumLiteralComparison(pass, x)
+		}
+
+		return true
+	})
+}
+
+// isProtoEnum checks that the type is a generated proto enum,
+// which has the Enum and Descriptor methods.
+func isProtoEnum(t types.Type) (*types.Named, bool) {
+	named, ok := t.(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return nil, false
+	}
+
+	if _, ok := named.Underlying().(*types.Basic); !ok {
+		return nil, false
+	}
+
+	return named, namedHasMethod(named, "Enum") && namedHasMethod(named, "Descriptor")
+}
+
+// enumConstants returns the constants of the enum declared in its package, grouped by value.
+func enumConstants(enum *types.Named) map[string][]*types.Const {
+	consts := make(map[string][]*types.Const)
+
+	scope := enum.Obj().Pkg().Scope()
+	for _, name := range scope.Names() {
+		c, ok := scope.Lookup(name).(*types.Const)
+		if !ok || !types.Identical(c.Type(), enum) {
+			continue
+		}
+
+		key := c.Val().ExactString()
+		consts[key] = append(consts[key], c)
+	}
+
+	return consts
+}
+
+// preferredEnumConst returns the constant to use for the value,
+// preferring the `*_UNSPECIFIED` name for aliases of the zero value.
+func preferredEnumConst(consts []*types.Const) *types.Const {
+	for _, c := range consts {
+		if strings.HasSuffix(c.Name(), "_UNSPECIFIED") {
+			return c
+		}
+	}
+
+	return consts[0]
+}
+
+func checkEnumSwitch(pass *analysis.Pass, stmt *ast.SwitchStmt) {
+	if stmt.Tag == nil {
+		return
+	}
+
+	enum, ok := isProtoEnum(pass.TypesInfo.TypeOf(stmt.Tag))
+	if !ok {
+		return
+	}
+
+	covered := make(map[string]struct{})
+	for _, s := range stmt.Body.List {
+		clause := s.(*ast.CaseClause)
+		if clause.List == nil {
+			return
+		}
+
+		for _, expr := range clause.List {
+			if tv, ok := pass.TypesInfo.Types[expr]; ok && tv.Value != nil {
+				covered[tv.Value.ExactString()] = struct{}{}
+			}
+		}
+	}
+
+	file := fileOf(pass, stmt.Pos())
+
+	var missing []string
+	for value, consts := range enumConstants(enum) {
+		if _, ok := covered[value]; ok {
+			continue
+		}
+
+		missing = append(missing, constString(pass.Pkg, file, preferredEnumConst(consts)))
+	}
+	if len(missing) == 0 {
+		return
+	}
+
+	sort.Strings(missing)
+
+	pass.Report(analysis.Diagnostic{
+		Pos:      stmt.Pos(),
+		End:      stmt.End(),
+		Category: "enum-exhaustive",
+		Message:  fmt.Sprintf(enumSwitchMsgFormat, formatNode(stmt.Tag), strings.Join(missing, ", ")),
+	})
+}
+
+func checkEnumConversion(pass *analysis.Pass, call *ast.CallExpr, stack []ast.Node) {
+	if len(call.Args) != 1 {
+		return
+	}
+
+	tv, ok := pass.TypesInfo.Types[call.Fun]
+	if !ok || !tv.IsType() {
+		return
+	}
+
+	enum, ok := isProtoEnum(tv.Type)
+	if !ok {
+		return
+	}
+
+	arg := call.Args[0]
+	argTV, ok := pass.TypesInfo.Types[arg]
+	if !ok {
+		return
+	}
+
+	file := fileOf(pass, call.Pos())
+	enumName := caseTypeString(pass.Pkg, file, enum)
+
+	// Constants are checked against the known values. Note that the type of an untyped constant
+	// argument is recorded as the enum itself, so it must be checked before the type.
+	if argTV.Value != nil {
+		if _, ok := enumConstants(enum)[argTV.Value.ExactString()]; ok {
+			return
+		}
+
+		pass.Report(analysis.Diagnostic{
+			Pos:      call.Pos(),
+			End:      call.End(),
+			Category: "enum-conversion",
+			Message:  fmt.Sprintf(enumUnknownMsgFormat, formatNode(arg), enumName),
+		})
+		return
+	}
+
+	if types.Identical(argTV.Type, enum) {
+		return
+	}
+
+	if _, ok := isProtoEnum(argTV.Type); ok {
+		// Converting between enums is a different mistake, leave it to the reviewers.
+		return
+	}
+
+	if isEnumValueChecked(pass.TypesInfo, enum, arg, call, stack) {
+		return
+	}
+
+	pass.Report(analysis.Diagnostic{
+		Pos:      call.Pos(),
+		End:      call.End(),
+		Category: "enum-conversion",
+		Message:  fmt.Sprintf(enumConversionMsgFormat, formatNode(arg), enumName, enumName),
+	})
+}
+
+// isEnumValueChecked reports whether the same variable is looked up in the generated `<Enum>_name` map
+// earlier in the function, like `if _, ok := pb.Enum_name[int32(v)]; ok {}`.
+func isEnumValueChecked(info *types.Info, enum *types.Named, arg ast.Expr, call *ast.CallExpr, stack []ast.Node) bool {
+	body := enclosingFuncBody(stack)
+	if body == nil {
+		return false
+	}
+
+	argObj := convertedObject(info, arg)
+	if argObj == nil {
+		return false
+	}
+
+	mapName := enum.Obj().Name() + "_name"
+
+	checked := false
+	ast.Inspect(body, func(n ast.Node) bool {
+		if checked || n == nil || n.Pos() >= call.Pos() {
+			return false
+		}
+
+		index, ok := n.(*ast.IndexExpr)
+		if !ok {
+			return true
+		}
+
+		var obj types.Object
+		switch x := index.X.(type) {
+		case *ast.Ident:
+			obj = info.ObjectOf(x)
+		case *ast.SelectorExpr:
+			obj = info.ObjectOf(x.Sel)
+		}
+
+		if obj != nil && obj.Pkg() == enum.Obj().Pkg() && obj.Name() == mapName && convertedObject(info, index.Index) == argObj {
+			checked = true
+		}
+
+		return true
+	})
+
+	return checked
+}
+
+// convertedObject returns the object of the variable the expression converts, like `v` for `int32(v)`.
+func convertedObject(info *types.Info, expr ast.Expr) types.Object {
+	switch x := ast.Unparen(expr).(type) {
+	case *ast.Ident:
+		return info.ObjectOf(x)
+
+	case *ast.CallExpr:
+		if tv, ok := info.Types[x.Fun]; ok && tv.IsType() && len(x.Args) == 1 {
+			return convertedObject(info, x.Args[0])
+		}
+	}
+
+	return nil
+}
+
+func checkEnumLiteralComparison(pass *analysis.Pass, expr *ast.BinaryExpr) {
+	switch expr.Op {
+	case token.EQL, token.NEQ, token.LSS, token.LEQ, token.GTR, token.GEQ:
+	default:
+		return
+	}
+
+	enumSide, literal := expr.X, expr.Y
+	enum, ok := isProtoEnum(pass.TypesInfo.TypeOf(enumSide))
+	if !ok || !isIntLiteral(literal) {
+		enumSide, literal = expr.Y, expr.X
+		enum, ok = isProtoEnum(pass.TypesInfo.TypeOf(enumSide))
+		if !ok || !isIntLiteral(literal) {
+			return
+		}
+	}
+
+	value := pass.TypesInfo.Types[literal].Value
+	if value == nil {
+		return
+	}
+
+	consts, ok := enumConstants(enum)[value.ExactString()]
+	if !ok {
+		pass.Report(analysis.Diagnostic{
+			Pos:      expr.Pos(),
+			End:      expr.End(),
+			Category: "enum-literal",
+			Message:  fmt.Sprintf(enumLiteralNoConstMsg, formatNode(enumSide), formatNode(literal)),
+		})
+		return
+	}
+
+	file := fileOf(pass, expr.Pos())
+	c := preferredEnumConst(consts)
+	name := constString(pass.Pkg, file, c)
+
+	// The enum may come from a getter of a message whose package the file doesn't import.
+	var importEdits []analysis.TextEdit
+	if file != nil {
+		if _, found := packageName(pass.Pkg, file, c.Pkg()); !found {
+			var pkgName string
+			pkgName, importEdits = addNamedImport(file, c.Pkg().Path(), c.Pkg().Name())
+			name = pkgName + "." + c.Name()
+		}
+	}
+
+	msg := fmt.Sprintf(enumLiteralMsgFormat, formatNode(enumSide), formatNode(literal), name)
+
+	pass.Report(analysis.Diagnostic{
+		Pos:      expr.Pos(),
+		End:      expr.End(),
+		Category: "enum-literal",
+		Message:  msg,
+		SuggestedFixes: []analysis.SuggestedFix{
+			{
+				Message: msg,
+				TextEdits: append([]analysis.TextEdit{
+					{
+						Pos:     literal.Pos(),
+						End:     literal.End(),
+						NewText: []byte(name),
+					},
+				}, importEdits...),
+			},
+		},
+	})
+}
+
+func isIntLiteral(expr ast.Expr) bool {
+	switch x := expr.(type) {
+	case *ast.ParenExpr:
+		return isIntLiteral(x.X)
+	case *ast.UnaryExpr:
+		return x.Op == token.SUB && isIntLiteral(x.X)
+	case *ast.BasicLit:
+		return x.Kind == token.INT
+	}
+
+	return false
+}
@@ -0,0 +1,380 @@
+package protogetter
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+const (
+	fieldMaskUnknownMsgFormat    = "unknown field %q in message %s of field mask path %q"
+	fieldMaskNotMessageMsgFormat = "field %q of message %s in field mask path %q is not a message"
+	fieldMaskRepeatedMsgFormat   = "repeated field %q of message %s in field mask path %q must be the last segment"
+)
+
+// didYouMeanSuffix is appended to the reports of unknown names with a close known name.
+const didYouMeanSuffix = ", did you mean %q?"
+
+const fieldmaskpbPkgPath = "google.golang.org/protobuf/types/known/fieldmaskpb"
+
+// checkFieldMasks validates the constant field mask paths against the descriptor of the message
+// embedded into the generated file: the arguments of `fieldmaskpb.New` and `Append`,
+// and the `fieldmaskpb.FieldMask` literals checked with `IsValid` or set in a request
+// with a single message field, like `&pb.UpdateFooRequest{Foo: f, UpdateMask: &fieldmaskpb.FieldMask{...}}`.
+func checkFieldMasks(pass *analysis.Pass, ins *inspector.Inspector) {
+	files := make(generatedFiles)
+	literals := collectFieldMaskLiterals(pass, ins)
+
+	// reported prevents reporting a literal path checked both with IsValid and in a request.
+	reported := make(map[token.Pos]struct{})
+
+	nodeTypes := []ast.Node{
+		(*ast.CallExpr)(nil),
+		(*ast.KeyValueExpr)(nil),
+	}
+
+	ins.Preorder(nodeTypes, func(n ast.Node) {
+		switch x := n.(type) {
+		case *ast.CallExpr:
+			fn, ok := typeutil.Callee(pass.TypesInfo, x).(*types.Func)
+			if !ok || fn.Pkg() == nil || fn.Pkg().Path() != fieldmaskpbPkgPath || len(x.Args) == 0 {
+				return
+			}
+
+			msgType := pass.TypesInfo.TypeOf(x.Args[0])
+
+			switch fn.Name() {
+			case "New", "Append":
+				checkFieldMaskPaths(pass, files, reported, msgType, variadicArgs(x, 1))
+
+			case "IsValid":
+				sel, ok := x.Fun.(*ast.SelectorExpr)
+				if !ok {
+					return
+				}
+
+				if lit := fieldMaskLiteral(pass.TypesInfo, literals, sel.X); lit != nil {
+					checkFieldMaskPaths(pass, files, reported, msgType, fieldMaskLiteralPaths(lit))
+				}
+			}
+
+		case *ast.KeyValueExpr:
+			lit := fieldMaskLiteral(pass.TypesInfo, nil, x.Value)
+			if lit == nil {
+				return
+			}
+
+			if msgType := requestMessageField(pass, files, x); msgType != nil {
+				checkFieldMaskPaths(pass, files, reported, msgType, fieldMaskLiteralPaths(lit))
+			}
+		}
+	})
+}
+
+// collectFieldMaskLiterals finds the variables assigned from a field mask literal, like `mask := &fieldmaskpb.FieldMask{...}`.
+func collectFieldMaskLiterals(pass *analysis.Pass, ins *inspector.Inspector) map[types.Object]*ast.CompositeLit {
+	literals := make(map[types.Object]*ast.CompositeLit)
+
+	ins.Preorder([]ast.Node{(*ast.AssignStmt)(nil)}, func(n ast.Node) {
+		assign := n.(*ast.AssignStmt)
+		if len(assign.Lhs) != len(assign.Rhs) {
+			return
+		}
+
+		for i, lhs := range assign.Lhs {
+			ident, ok := lhs.(*ast.Ident)
+			if !ok {
+				continue
+			}
+
+			if lit := fieldMaskLiteral(pass.TypesInfo, nil, assign.Rhs[i]); lit != nil {
+				if obj := pass.TypesInfo.ObjectOf(ident); obj != nil {
+					literals[obj] = lit
+				}
+			}
+		}
+	})
+
+	return literals
+}
+
+// fieldMaskLiteral returns the `fieldmaskpb.FieldMask` literal of the expression, written in place
+// or assigned to the variable.
+func fieldMaskLiteral(info *types.Info, literals map[types.Object]*ast.CompositeLit, expr ast.Expr) *ast.CompositeLit {
+	expr = ast.Unparen(expr)
+	if ident, ok := expr.(*ast.Ident); ok {
+		return literals[info.ObjectOf(ident)]
+	}
+
+	if u, ok := expr.(*ast.UnaryExpr); ok && u.Op == token.AND {
+		expr = u.X
+	}
+
+	lit, ok := expr.(*ast.CompositeLit)
+	if !ok {
+		return nil
+	}
+
+	named, ok := namedType(info.TypeOf(lit))
+	if !ok || named.Obj().Pkg() == nil || named.Obj().Pkg().Path() != fieldmaskpbPkgPath || named.Obj().Name() != "FieldMask" {
+		return nil
+	}
+
+	return lit
+}
+
+// fieldMaskLiteralPaths returns the elements of the `Paths: []string{...}` of the literal.
+func fieldMaskLiteralPaths(lit *ast.CompositeLit) []ast.Expr {
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+
+		key, ok := kv.Key.(*ast.Ident)
+		if !ok || key.Name != "Paths" {
+			continue
+		}
+
+		if paths, ok := kv.Value.(*ast.CompositeLit); ok {
+			return paths.Elts
+		}
+	}
+
+	return nil
+}
+
+// variadicArgs returns the arguments starting with the index, expanding `f(m, []string{...}...)`.
+func variadicArgs(call *ast.CallExpr, from int) []ast.Expr {
+	if len(call.Args) <= from {
+		return nil
+	}
+
+	if call.Ellipsis.IsValid() {
+		if lit, ok := call.Args[len(call.Args)-1].(*ast.CompositeLit); ok {
+			return lit.Elts
+		}
+		return nil
+	}
+
+	return call.Args[from:]
+}
+
+// requestMessageField returns the type of the only message field, other than the field masks,
+// of the message literal the key value belongs to.
+func requestMessageField(pass *analysis.Pass, files generatedFiles, kv *ast.KeyValueExpr) types.Type {
+	file := fileOf(pass, kv.Pos())
+	if file == nil {
+		return nil
+	}
+
+	path, _ := astutil.PathEnclosingInterval(file, kv.Pos(), kv.End())
+	if len(path) < 2 {
+		return nil
+	}
+
+	lit, ok := path[1].(*ast.CompositeLit)
+	if !ok {
+		return nil
+	}
+
+	named, ok := namedType(pass.TypesInfo.TypeOf(lit))
+	if !ok || !isProtoMessageType(named) {
+		return nil
+	}
+
+	f := files.of(pass.Fset, named.Obj())
+	if f == nil {
+		return nil
+	}
+
+	desc := f.message(named.Obj().Name())
+	if desc == nil {
+		return nil
+	}
+
+	var found types.Type
+	for _, field := range desc.GetField() {
+		if field.GetType() != descriptorpb.FieldDescriptorProto_TYPE_MESSAGE ||
+			field.GetLabel() == descriptorpb.FieldDescriptorProto_LABEL_REPEATED ||
+			field.GetTypeName() == ".google.protobuf.FieldMask" {
+			continue
+		}
+
+		if found != nil {
+			return nil
+		}
+
+		found = getterResult(named, goCamelCase(field.GetName()))
+		if found == nil {
+			return nil
+		}
+	}
+
+	return found
+}
+
+// checkFieldMaskPaths reports the constant paths not matching the fields of the message.
+func checkFieldMaskPaths(pass *analysis.Pass, files generatedFiles, reported map[token.Pos]struct{}, msgType types.Type, paths []ast.Expr) {
+	if !isProtoMessageType(msgType) {
+		return
+	}
+
+	for _, expr := range paths {
+		path, ok := constantString(pass.TypesInfo, expr)
+		if !ok {
+			continue
+		}
+
+		if _, ok := reported[expr.Pos()]; ok {
+			continue
+		}
+
+		msg, ok := validateFieldMaskPath(pass, files, msgType, path)
+		if ok {
+			continue
+		}
+
+		reported[expr.Pos()] = struct{}{}
+		pass.Report(analysis.Diagnostic{
+			Pos:      expr.Pos(),
+			End:      expr.End(),
+			Category: "field-mask",
+			Message:  msg,
+		})
+	}
+}
+
+// validateFieldMaskPath walks the path through the message descriptors the same way fieldmaskpb does.
+// The paths of messages without a known descriptor are considered valid.
+func validateFieldMaskPath(pass *analysis.Pass, files generatedFiles, msgType types.Type, path string) (string, bool) {
+	segments := strings.Split(path, ".")
+
+	named, _ := namedType(msgType)
+	for i, segment := range segments {
+		f := files.of(pass.Fset, named.Obj())
+		if f == nil {
+			return "", true
+		}
+
+		desc := f.message(named.Obj().Name())
+		msgName, _ := f.messageFullName(named.Obj().Name())
+		if desc == nil {
+			return "", true
+		}
+
+		var field *descriptorpb.FieldDescriptorProto
+		for _, fd := range desc.GetField() {
+			if fd.GetName() == segment {
+				field = fd
+				break
+			}
+		}
+
+		if field == nil {
+			msg := fmt.Sprintf(fieldMaskUnknownMsgFormat, segment, msgName, path)
+			if suggestion, ok := closestName(fieldNames(desc.GetField()), segment); ok {
+				msg += fmt.Sprintf(didYouMeanSuffix, suggestion)
+			}
+			return msg, false
+		}
+
+		if i == len(segments)-1 {
+			return "", true
+		}
+
+		if field.GetLabel() == descriptorpb.FieldDescriptorProto_LABEL_REPEATED {
+			return fmt.Sprintf(fieldMaskRepeatedMsgFormat, segment, msgName, path), false
+		}
+
+		if field.GetType() != descriptorpb.FieldDescriptorProto_TYPE_MESSAGE && field.GetType() != descriptorpb.FieldDescriptorProto_TYPE_GROUP {
+			return fmt.Sprintf(fieldMaskNotMessageMsgFormat, segment, msgName, path), false
+		}
+
+		next, ok := namedType(getterResult(named, goCamelCase(field.GetName())))
+		if !ok {
+			return "", true
+		}
+		named = next
+	}
+
+	return "", true
+}
+
+// getterResult returns the result type of the `Get<name>()` method of the message, if any.
+func getterResult(named *types.Named, name string) types.Type {
+	obj, _, _ := types.LookupFieldOrMethod(types.NewPointer(named), false, named.Obj().Pkg(), "Get"+name)
+	fn, ok := obj.(*types.Func)
+	if !ok {
+		return nil
+	}
+
+	sig := fn.Type().(*types.Signature)
+	if sig.Results().Len() != 1 {
+		return nil
+	}
+
+	return sig.Results().At(0).Type()
+}
+
+// fieldNames returns the names of the fields.
+func fieldNames(fields []*descriptorpb.FieldDescriptorProto) []string {
+	names := make([]string, 0, len(fields))
+	for _, fd := range fields {
+		names = append(names, fd.GetName())
+	}
+
+	return names
+}
+
+// closestName returns the name differing only by the case and the underscores,
+// like the JSON name of a field, or the closest name within two edits.
+func closestName(names []string, name string) (string, bool) {
+	normalize := func(s string) string {
+		return strings.ToLower(strings.ReplaceAll(s, "_", ""))
+	}
+
+	best, bestDistance := "", 3
+	for _, candidate := range names {
+		if normalize(candidate) == normalize(name) {
+			return candidate, true
+		}
+
+		if d := editDistance(candidate, name); d < bestDistance {
+			best, bestDistance = candidate, d
+		}
+	}
+
+	return best, best != ""
+}
+
+// editDistance returns the Levenshtein distance between the strings.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+
+	return prev[len(b)]
+}
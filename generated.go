@@ -0,0 +1,452 @@
+package protogetter
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/astutil"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// generatedFile is a generated .pb.go file parsed from the disk.
+// The comments and the raw descriptor of the imported packages are not available
+// from the type information, so the file is parsed again.
+type generatedFile struct {
+	fset *token.FileSet
+	file *ast.File
+	// desc is the descriptor embedded into the file, nil if the file has none.
+	desc *descriptorpb.FileDescriptorProto
+}
+
+// generatedFiles caches the parsed files by name, a nil value means the file can't be parsed.
+type generatedFiles map[string]*generatedFile
+
+// of returns the parsed file declaring the object.
+func (g generatedFiles) of(fset *token.FileSet, obj types.Object) *generatedFile {
+	if !obj.Pos().IsValid() {
+		return nil
+	}
+
+	filename := fset.Position(obj.Pos()).Filename
+	if f, ok := g[filename]; ok {
+		return f
+	}
+
+	var f *generatedFile
+	defer func() { g[filename] = f }()
+
+	if !strings.HasSuffix(filename, ".pb.go") {
+		return nil
+	}
+
+	fileFset := token.NewFileSet()
+	file, err := parser.ParseFile(fileFset, filename, nil, parser.ParseComments)
+	if err != nil {
+		return nil
+	}
+
+	f = &generatedFile{
+		fset: fileFset,
+		file: file,
+	}
+
+	if raw, ok := rawDescriptor(file); ok {
+		desc := new(descriptorpb.FileDescriptorProto)
+		if err := proto.Unmarshal(raw, desc); err == nil {
+			f.desc = desc
+		}
+	}
+
+	return f
+}
+
+// missing returns the name of the generated file declaring the object when it can't be read,
+// like the files of packages built with -trimpath, whose positions don't point to the sources.
+// The comments and the descriptor of such files are unknown, so the callers report it
+// instead of silently skipping the objects.
+func (g generatedFiles) missing(fset *token.FileSet, obj types.Object) (string, bool) {
+	if !obj.Pos().IsValid() || g.of(fset, obj) != nil {
+		return "", false
+	}
+
+	filename := fset.Position(obj.Pos()).Filename
+	if !strings.HasSuffix(filename, ".pb.go") {
+		return "", false
+	}
+
+	_, err := os.Stat(filename)
+	return filename, err != nil
+}
+
+// messageResolver finds the Go message types by their full proto names
+// among the messages of the package and its imports.
+type messageResolver struct {
+	pass  *analysis.Pass
+	files generatedFiles
+
+	// byName and packages are collected on the first lookup.
+	byName   map[string]*types.TypeName
+	packages map[string]struct{}
+}
+
+func newMessageResolver(pass *analysis.Pass, files generatedFiles) *messageResolver {
+	return &messageResolver{pass: pass, files: files}
+}
+
+// lookup returns the message type with the full name, like `foo.v1.Bar`.
+func (r *messageResolver) lookup(fullName string) (*types.TypeName, bool) {
+	r.init()
+
+	obj, ok := r.byName[fullName]
+	return obj, ok
+}
+
+// hasPackage reports whether some message of the proto package is known.
+func (r *messageResolver) hasPackage(pkg string) bool {
+	r.init()
+
+	_, ok := r.packages[pkg]
+	return ok
+}
+
+// names returns the full names of the known messages.
+func (r *messageResolver) names() []string {
+	r.init()
+
+	names := make([]string, 0, len(r.byName))
+	for name := range r.byName {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+func (r *messageResolver) init() {
+	if r.byName != nil {
+		return
+	}
+
+	r.byName = make(map[string]*types.TypeName)
+	r.packages = make(map[string]struct{})
+	for _, pkg := range append([]*types.Package{r.pass.Pkg}, r.pass.Pkg.Imports()...) {
+		scope := pkg.Scope()
+		for _, name := range scope.Names() {
+			obj, ok := scope.Lookup(name).(*types.TypeName)
+			if !ok || !isProtoMessageType(types.NewPointer(obj.Type())) {
+				continue
+			}
+
+			f := r.files.of(r.pass.Fset, obj)
+			if f == nil {
+				continue
+			}
+
+			if fullName, ok := f.messageFullName(obj.Name()); ok {
+				r.byName[fullName] = obj
+				r.packages[f.desc.GetPackage()] = struct{}{}
+			}
+		}
+	}
+}
+
+// path returns the path of nodes enclosing the declaration of the object, starting with its identifier.
+func (f *generatedFile) path(fset *token.FileSet, obj types.Object) []ast.Node {
+	position := fset.Position(obj.Pos())
+
+	tokFile := f.fset.File(f.file.Pos())
+	if position.Line < 1 || position.Line > tokFile.LineCount() {
+		return nil
+	}
+
+	pos := tokFile.LineStart(position.Line) + token.Pos(position.Column-1)
+	path, _ := astutil.PathEnclosingInterval(f.file, pos, pos+1)
+	if len(path) == 0 {
+		return nil
+	}
+
+	if ident, ok := path[0].(*ast.Ident); !ok || ident.Name != obj.Name() {
+		return nil
+	}
+
+	return path
+}
+
+// message returns the descriptor of the message generated as the Go type with the name.
+func (f *generatedFile) message(goName string) *descriptorpb.DescriptorProto {
+	msg, _ := f.findMessage(goName)
+	return msg
+}
+
+// messageFullName returns the full proto name of the message generated as the Go type with the name, like `foo.Bar.Baz`.
+func (f *generatedFile) messageFullName(goName string) (string, bool) {
+	msg, fullName := f.findMessage(goName)
+	return fullName, msg != nil
+}
+
+// registeredName returns the full proto name the message generated as the Go type with the name
+// is registered with, like `proto.RegisterType((*Bar)(nil), "foo.Bar")` in the files of
+// protoc-gen-gogo and old protoc-gen-go versions, which embed no raw descriptor.
+func (f *generatedFile) registeredName(goName string) (string, bool) {
+	var (
+		fullName string
+		found    bool
+	)
+
+	ast.Inspect(f.file, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || found || len(call.Args) != 2 {
+			return !found
+		}
+
+		fun, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok || fun.Sel.Name != "RegisterType" {
+			return true
+		}
+
+		// `(*Bar)(nil)`
+		conv, ok := ast.Unparen(call.Args[0]).(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+
+		star, ok := ast.Unparen(conv.Fun).(*ast.StarExpr)
+		if !ok {
+			return true
+		}
+
+		ident, ok := star.X.(*ast.Ident)
+		if !ok || ident.Name != goName {
+			return true
+		}
+
+		lit, ok := call.Args[1].(*ast.BasicLit)
+		if !ok || lit.Kind != token.STRING {
+			return true
+		}
+
+		name, err := strconv.Unquote(lit.Value)
+		if err != nil {
+			return true
+		}
+
+		fullName, found = name, true
+		return false
+	})
+
+	return fullName, found
+}
+
+func (f *generatedFile) findMessage(goName string) (*descriptorpb.DescriptorProto, string) {
+	if f.desc == nil {
+		return nil, ""
+	}
+
+	var find func(goPrefix, protoPrefix string, msgs []*descriptorpb.DescriptorProto) (*descriptorpb.DescriptorProto, string)
+	find = func(goPrefix, protoPrefix string, msgs []*descriptorpb.DescriptorProto) (*descriptorpb.DescriptorProto, string) {
+		for _, msg := range msgs {
+			name := goPrefix + goCamelCase(msg.GetName())
+			if name == goName {
+				return msg, protoPrefix + msg.GetName()
+			}
+
+			if strings.HasPrefix(goName, name+"_") {
+				if nested, fullName := find(name+"_", protoPrefix+msg.GetName()+".", msg.GetNestedType()); nested != nil {
+					return nested, fullName
+				}
+			}
+		}
+
+		return nil, ""
+	}
+
+	var protoPrefix string
+	if pkg := f.desc.GetPackage(); pkg != "" {
+		protoPrefix = pkg + "."
+	}
+
+	return find("", protoPrefix, f.desc.GetMessageType())
+}
+
+// enum returns the descriptor of the enum generated as the Go type with the name.
+func (f *generatedFile) enum(goName string) *descriptorpb.EnumDescriptorProto {
+	if f.desc == nil {
+		return nil
+	}
+
+	var find func(prefix string, enums []*descriptorpb.EnumDescriptorProto, msgs []*descriptorpb.DescriptorProto) *descriptorpb.EnumDescriptorProto
+	find = func(prefix string, enums []*descriptorpb.EnumDescriptorProto, msgs []*descriptorpb.DescriptorProto) *descriptorpb.EnumDescriptorProto {
+		for _, enum := range enums {
+			if prefix+goCamelCase(enum.GetName()) == goName {
+				return enum
+			}
+		}
+
+		for _, msg := range msgs {
+			name := prefix + goCamelCase(msg.GetName())
+			if !strings.HasPrefix(goName, name+"_") {
+				continue
+			}
+
+			if enum := find(name+"_", msg.GetEnumType(), msg.GetNestedType()); enum != nil {
+				return enum
+			}
+		}
+
+		return nil
+	}
+
+	return find("", f.desc.GetEnumType(), f.desc.GetMessageType())
+}
+
+// rawDescriptor returns the bytes of the `file_*_rawDesc` variable, generated as
+// a byte slice, a string conversion of a byte slice, or a concatenation of strings
+// depending on the protoc-gen-go version.
+func rawDescriptor(file *ast.File) ([]byte, bool) {
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || (gen.Tok != token.VAR && gen.Tok != token.CONST) {
+			continue
+		}
+
+		for _, spec := range gen.Specs {
+			vs := spec.(*ast.ValueSpec)
+			if len(vs.Names) != 1 || len(vs.Values) != 1 {
+				continue
+			}
+
+			name := vs.Names[0].Name
+			if strings.HasPrefix(name, "file_") && strings.HasSuffix(name, "_rawDesc") {
+				return literalBytes(vs.Values[0])
+			}
+		}
+	}
+
+	return nil, false
+}
+
+func literalBytes(expr ast.Expr) ([]byte, bool) {
+	switch x := expr.(type) {
+	case *ast.ParenExpr:
+		return literalBytes(x.X)
+
+	case *ast.CallExpr:
+		// string([]byte{...}) or []byte("...").
+		if len(x.Args) != 1 {
+			return nil, false
+		}
+		return literalBytes(x.Args[0])
+
+	case *ast.CompositeLit:
+		b := make([]byte, 0, len(x.Elts))
+		for _, elt := range x.Elts {
+			lit, ok := elt.(*ast.BasicLit)
+			if !ok || lit.Kind != token.INT {
+				return nil, false
+			}
+
+			v, err := strconv.ParseUint(lit.Value, 0, 8)
+			if err != nil {
+				return nil, false
+			}
+			b = append(b, byte(v))
+		}
+		return b, true
+
+	case *ast.BinaryExpr:
+		if x.Op != token.ADD {
+			return nil, false
+		}
+
+		left, ok := literalBytes(x.X)
+		if !ok {
+			return nil, false
+		}
+
+		right, ok := literalBytes(x.Y)
+		if !ok {
+			return nil, false
+		}
+		return append(left, right...), true
+
+	case *ast.BasicLit:
+		if x.Kind != token.STRING {
+			return nil, false
+		}
+
+		s, err := strconv.Unquote(x.Value)
+		if err != nil {
+			return nil, false
+		}
+		return []byte(s), true
+	}
+
+	return nil, false
+}
+
+// goCamelCase converts the proto name to the Go name the same way protoc-gen-go does.
+func goCamelCase(s string) string {
+	var b []byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '.' && i+1 < len(s) && isASCIILower(s[i+1]):
+			// Skip over '.' in ".{{lowercase}}".
+		case c == '.':
+			b = append(b, '_')
+		case c == '_' && (i == 0 || s[i-1] == '.'):
+			// Convert initial '_' to ensure we start with a capital letter.
+			b = append(b, 'X')
+		case c == '_' && i+1 < len(s) && isASCIILower(s[i+1]):
+			// Skip over '_' in "_{{lowercase}}".
+		case isASCIIDigit(c):
+			b = append(b, c)
+		default:
+			// Assume we have a letter now - if not, it's a bogus identifier.
+			// The next word is a sequence of characters that must start upper case.
+			if isASCIILower(c) {
+				c -= 'a' - 'A'
+			}
+			b = append(b, c)
+
+			// Accept lower case sequence that follows.
+			for ; i+1 < len(s) && isASCIILower(s[i+1]); i++ {
+				b = append(b, s[i+1])
+			}
+		}
+	}
+	return string(b)
+}
+
+func isASCIILower(c byte) bool {
+	return 'a' <= c && c <= 'z'
+}
+
+func isASCIIDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+// fieldProtoName returns the proto name of the field from its `protobuf:"...,name=foo,..."` tag.
+func fieldProtoName(tag string) (string, bool) {
+	value, ok := reflect.StructTag(tag).Lookup("protobuf")
+	if !ok {
+		return "", false
+	}
+
+	for _, part := range strings.Split(value, ",") {
+		if name, ok := strings.CutPrefix(part, "name="); ok {
+			return name, true
+		}
+	}
+
+	return "", false
+}
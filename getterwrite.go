@@ -0,0 +1,119 @@
+package protogetter
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+const getterWriteMsgFormat = "avoid writing to %s, %s returns nil when the field is not set"
+
+func checkGetterWrites(pass *analysis.Pass, ins *inspector.Inspector) {
+	nodeTypes := []ast.Node{
+		(*ast.AssignStmt)(nil),
+		(*ast.IncDecStmt)(nil),
+		(*ast.CallExpr)(nil),
+	}
+
+	ins.Preorder(nodeTypes, func(n ast.Node) {
+		switch x := n.(type) {
+		case *ast.AssignStmt:
+			if x.Tok == token.DEFINE {
+				return
+			}
+
+			for _, lhs := range x.Lhs {
+				reportGetterWrite(pass, lhs, writeTarget(lhs))
+			}
+
+		case *ast.IncDecStmt:
+			reportGetterWrite(pass, x.X, writeTarget(x.X))
+
+		case *ast.CallExpr:
+			fun, ok := x.Fun.(*ast.SelectorExpr)
+			if !ok || !isMutatingMethod(fun.Sel.Name) || !isProtoMessage(pass.TypesInfo, fun.X) {
+				return
+			}
+
+			reportGetterWrite(pass, x, fun.X)
+		}
+	})
+}
+
+func reportGetterWrite(pass *analysis.Pass, node, receiver ast.Expr) {
+	getter := messageGetterInChain(pass.TypesInfo, receiver)
+	if getter == nil {
+		return
+	}
+
+	pass.Report(analysis.Diagnostic{
+		Pos:      node.Pos(),
+		End:      node.End(),
+		Category: "getter-write",
+		Message:  fmt.Sprintf(getterWriteMsgFormat, formatNode(node), formatNode(getter)),
+	})
+}
+
+// writeTarget returns the message whose field is written by the expression,
+// or nil if the expression does not write a proto field.
+func writeTarget(expr ast.Expr) ast.Expr {
+	for {
+		switch x := expr.(type) {
+		case *ast.ParenExpr:
+			expr = x.X
+		case *ast.StarExpr:
+			expr = x.X
+		case *ast.IndexExpr:
+			expr = x.X
+		case *ast.SelectorExpr:
+			return x.X
+		default:
+			return nil
+		}
+	}
+}
+
+// messageGetterInChain returns the closest getter call in the selector chain
+// that returns a message pointer, since such a getter returns nil for an unset field.
+func messageGetterInChain(info *types.Info, expr ast.Expr) *ast.CallExpr {
+	for expr != nil {
+		switch x := expr.(type) {
+		case *ast.ParenExpr:
+			expr = x.X
+
+		case *ast.SelectorExpr:
+			expr = x.X
+
+		case *ast.CallExpr:
+			if isMessageGetterCall(info, x) {
+				return x
+			}
+			return nil
+
+		default:
+			return nil
+		}
+	}
+
+	return nil
+}
+
+func isMessageGetterCall(info *types.Info, call *ast.CallExpr) bool {
+	fun, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || len(call.Args) != 0 || !strings.HasPrefix(fun.Sel.Name, "Get") {
+		return false
+	}
+
+	return isProtoMessage(info, fun.X) && isProtoMessagePointer(info.TypeOf(call))
+}
+
+// isMutatingMethod reports whether the method modifies its receiver,
+// like the generated setters and Clear methods of the opaque API.
+func isMutatingMethod(name string) bool {
+	return strings.HasPrefix(name, "Set") || strings.HasPrefix(name, "Clear") || name == "Reset"
+}
@@ -5,6 +5,7 @@ go 1.24.0
 require (
 	github.com/gobwas/glob v0.2.3
 	golang.org/x/tools v0.37.0
+	google.golang.org/protobuf v1.36.5
 )
 
 require (
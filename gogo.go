@@ -0,0 +1,214 @@
+package protogetter
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+const gogoMsgFormat = "avoid direct access to gogo proto field %s, %s may be nil, use %s instead"
+
+// isGogoMessageType reports whether the type is a message generated by protoc-gen-gogo,
+// which isProtoMessageType leaves out.
+func isGogoMessageType(t types.Type) bool {
+	named, ok := namedType(t)
+	if !ok {
+		return false
+	}
+
+	return namedHasMethod(named, "ProtoMessage") && namedHasMethod(named, "MarshalToSizedBuffer")
+}
+
+// checkGogoGetters reports the reads through pointer fields of gogo messages, like `m.Foo.Bar`,
+// where the getters prevent a panic: `m.GetFoo().GetBar()`. Unlike protoc-gen-go, gogo generates no getters
+// for `customtype` fields and may be told to generate none at all, so each getter has to exist and check
+// its receiver for nil. The `nullable=false` fields are values that can't be nil, so reads through them are left as is.
+func checkGogoGetters(pass *analysis.Pass, ins *inspector.Inspector) {
+	files := make(generatedFiles)
+	nilSafe := make(map[*types.Func]bool)
+
+	ins.WithStack([]ast.Node{(*ast.SelectorExpr)(nil)}, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+
+		sel := n.(*ast.SelectorExpr)
+		if len(stack) > 1 && isGogoChainWrite(stack[len(stack)-2], sel) {
+			return true
+		}
+
+		// The chain is checked once, from its outermost selector.
+		chain := gogoFieldChain(pass.TypesInfo, sel)
+		if len(chain) < 2 {
+			return true
+		}
+
+		var (
+			nilable   ast.Expr
+			converted = make(map[*ast.SelectorExpr]struct{})
+		)
+
+		for _, s := range chain[1:] {
+			if _, ok := pass.TypesInfo.TypeOf(s.X).Underlying().(*types.Pointer); !ok {
+				continue
+			}
+
+			named, _ := namedType(pass.TypesInfo.TypeOf(s.X))
+			getter, ok := messageMethod(named, "Get"+s.Sel.Name)
+			if !ok {
+				return true
+			}
+
+			// The getter of a proto2 optional scalar, like `Count *int32`, returns the value, not the pointer.
+			if _, ok := pass.TypesInfo.TypeOf(s).Underlying().(*types.Pointer); ok {
+				if hasPointer, ok := getterResultHasPointer(pass.TypesInfo, s.X, s.Sel.Name); !ok || !hasPointer {
+					return true
+				}
+			}
+
+			safe, ok := nilSafe[getter]
+			if !ok {
+				safe = isNilSafeGetter(pass, files, getter)
+				nilSafe[getter] = safe
+			}
+
+			if !safe {
+				return true
+			}
+
+			converted[s] = struct{}{}
+			if nilable == nil {
+				nilable = s.X
+			}
+		}
+
+		if nilable == nil {
+			return true
+		}
+
+		replacement := gogoGetterChain(sel, converted)
+		msg := fmt.Sprintf(gogoMsgFormat, formatNode(sel), formatNode(nilable), replacement)
+		pass.Report(analysis.Diagnostic{
+			Pos:      sel.Pos(),
+			End:      sel.End(),
+			Category: "gogo",
+			Message:  msg,
+			SuggestedFixes: []analysis.SuggestedFix{
+				{
+					Message: msg,
+					TextEdits: []analysis.TextEdit{
+						{
+							Pos:     sel.Pos(),
+							End:     sel.End(),
+							NewText: []byte(replacement),
+						},
+					},
+				},
+			},
+		})
+
+		return true
+	})
+}
+
+// gogoFieldChain returns the field selectors of gogo messages ending with the selector, innermost first,
+// like `m.Foo`, `m.Foo.Bar`.
+func gogoFieldChain(info *types.Info, sel *ast.SelectorExpr) []*ast.SelectorExpr {
+	var chain []*ast.SelectorExpr
+	for expr := ast.Expr(sel); ; {
+		s, ok := expr.(*ast.SelectorExpr)
+		if !ok {
+			break
+		}
+
+		selection, ok := info.Selections[s]
+		if !ok || selection.Kind() != types.FieldVal || !isGogoMessageType(selection.Recv()) {
+			break
+		}
+
+		chain = append([]*ast.SelectorExpr{s}, chain...)
+		expr = s.X
+	}
+
+	return chain
+}
+
+// isGogoChainWrite reports whether the parent writes or takes the address of the selector, where a getter doesn't help,
+// or continues the chain, which is checked from its outermost selector.
+func isGogoChainWrite(parent ast.Node, sel *ast.SelectorExpr) bool {
+	switch x := parent.(type) {
+	case *ast.SelectorExpr:
+		return x.X == sel
+	case *ast.UnaryExpr:
+		return x.Op == token.AND
+	case *ast.IncDecStmt:
+		return true
+	case *ast.AssignStmt:
+		for _, lhs := range x.Lhs {
+			if lhs == sel {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// gogoGetterChain writes the chain with the getters of the converted selectors, like `m.GetFoo().GetBar()`.
+func gogoGetterChain(expr ast.Expr, converted map[*ast.SelectorExpr]struct{}) string {
+	sel, ok := expr.(*ast.SelectorExpr)
+	if !ok {
+		return formatNode(expr)
+	}
+
+	if _, ok := converted[sel]; ok {
+		return fmt.Sprintf("%s.Get%s()", gogoGetterChain(sel.X, converted), sel.Sel.Name)
+	}
+
+	return gogoGetterChain(sel.X, converted) + "." + sel.Sel.Name
+}
+
+// isNilSafeGetter reports whether the generated getter starts with checking its receiver for nil,
+// like `if m != nil { return m.Foo }`.
+func isNilSafeGetter(pass *analysis.Pass, files generatedFiles, getter *types.Func) bool {
+	f := files.of(pass.Fset, getter)
+	if f == nil {
+		return false
+	}
+
+	path := f.path(pass.Fset, getter)
+	if len(path) < 2 {
+		return false
+	}
+
+	decl, ok := path[1].(*ast.FuncDecl)
+	if !ok || decl.Recv == nil || len(decl.Recv.List) != 1 || len(decl.Recv.List[0].Names) != 1 || decl.Body == nil || len(decl.Body.List) == 0 {
+		return false
+	}
+
+	recv := decl.Recv.List[0].Names[0].Name
+
+	ifStmt, ok := decl.Body.List[0].(*ast.IfStmt)
+	if !ok {
+		return false
+	}
+
+	for _, cond := range conjuncts(ifStmt.Cond) {
+		cmp, ok := ast.Unparen(cond).(*ast.BinaryExpr)
+		if !ok || (cmp.Op != token.NEQ && cmp.Op != token.EQL) {
+			continue
+		}
+
+		for _, pair := range [][2]ast.Expr{{cmp.X, cmp.Y}, {cmp.Y, cmp.X}} {
+			if ident, ok := pair[0].(*ast.Ident); ok && ident.Name == recv && isNilIdent(pair[1]) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,480 @@
+package protogetter
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"reflect"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+const (
+	gogoFieldMsgFormat  = "%s: %s field becomes %s without gogo, %s"
+	gogoMethodMsgFormat = "%s: %s goes away without gogo, use %s instead, %s"
+)
+
+// gogoFieldOption is a gogoproto field option changing the generated Go field, and what the field becomes without it.
+type gogoFieldOption struct {
+	// name is the option, like `stdtime`.
+	name string
+	// becomes is the Go type generated by protoc-gen-go.
+	becomes string
+	// pkgPath and wrap convert a value of the gogo field to the protoc-gen-go one, like `timestamppb.New`.
+	pkgPath string
+	wrap    string
+	// unwrap reads the protoc-gen-go field as the gogo one, like `.AsTime()`.
+	unwrap string
+}
+
+// gogoUse is a use of a gogo field or method that breaks without gogo, with the edits fixing it, if any.
+type gogoUse struct {
+	node  ast.Node
+	edits []analysis.TextEdit
+	// pkgPath is imported by the edits.
+	pkgPath string
+}
+
+// gogoGroup collects the uses of a proto field or a method of a message.
+type gogoGroup struct {
+	// key is the Go package path and the label, so the messages of different packages don't mix.
+	key string
+	// label is the proto full name of the field or the method, like `foo.Event.created`.
+	label string
+	msg   func(uses int) string
+	uses  []gogoUse
+}
+
+// reportGogoMigration reports the uses of gogo-only features that break when moving to golang/protobuf:
+// `nullable=false`, `customtype`, `casttype`, `stdtime` and `stdduration` fields, and the `Equal` and `VerboseEqual` methods.
+// The uses are grouped by the proto field or the method into a single diagnostic at the first use,
+// with the other uses as the related information and the fixes of the uses that have one,
+// like `timestamppb.New(t)` for writes of a `stdtime` field and `m.GetCreated().AsTime()` for its reads.
+func reportGogoMigration(pass *analysis.Pass, ins *inspector.Inspector) {
+	groups := make(map[string]*gogoGroup)
+	files := make(generatedFiles)
+
+	add := func(named *types.Named, member string, msg func(label string, uses int) string, use gogoUse) {
+		label := gogoMessageName(pass, files, named) + "." + member
+		key := named.Obj().Pkg().Path() + " " + label
+
+		g, ok := groups[key]
+		if !ok {
+			g = &gogoGroup{
+				key:   key,
+				label: label,
+				msg:   func(uses int) string { return msg(label, uses) },
+			}
+			groups[key] = g
+		}
+		g.uses = append(g.uses, use)
+	}
+
+	nodeTypes := []ast.Node{
+		(*ast.SelectorExpr)(nil),
+		(*ast.KeyValueExpr)(nil),
+	}
+
+	ins.WithStack(nodeTypes, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+
+		var parent ast.Node
+		if len(stack) > 1 {
+			parent = stack[len(stack)-2]
+		}
+
+		switch x := n.(type) {
+		case *ast.SelectorExpr:
+			selection, ok := pass.TypesInfo.Selections[x]
+			if !ok || !isGogoMessageType(selection.Recv()) {
+				return true
+			}
+
+			named, _ := namedType(selection.Recv())
+
+			if selection.Kind() == types.MethodVal {
+				if use, replacement, ok := gogoEqualUse(pass, x, parent); ok {
+					method := x.Sel.Name
+					add(named, method, func(label string, uses int) string {
+						return fmt.Sprintf(gogoMethodMsgFormat, label, method, replacement, gogoUsesBreak(uses))
+					}, use)
+				}
+				return true
+			}
+
+			field, ok := selection.Obj().(*types.Var)
+			if !ok {
+				return true
+			}
+
+			name, option, ok := gogoFieldOptionOf(named, field)
+			if !ok {
+				return true
+			}
+
+			use, ok := gogoFieldUse(pass, x, parent, option)
+			if ok {
+				add(named, name, gogoFieldMsg(option), use)
+			}
+
+		case *ast.KeyValueExpr:
+			lit, ok := parent.(*ast.CompositeLit)
+			if !ok {
+				return true
+			}
+
+			named, ok := namedType(pass.TypesInfo.TypeOf(lit))
+			if !ok || !isGogoMessageType(named) {
+				return true
+			}
+
+			ident, ok := x.Key.(*ast.Ident)
+			if !ok {
+				return true
+			}
+
+			field, ok := pass.TypesInfo.ObjectOf(ident).(*types.Var)
+			if !ok {
+				return true
+			}
+
+			name, option, ok := gogoFieldOptionOf(named, field)
+			if !ok {
+				return true
+			}
+
+			add(named, name, gogoFieldMsg(option), gogoValueUse(pass, x, x.Value, option))
+		}
+
+		return true
+	})
+
+	sorted := make([]*gogoGroup, 0, len(groups))
+	for _, g := range groups {
+		sorted = append(sorted, g)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].key < sorted[j].key
+	})
+
+	for _, g := range sorted {
+		reportGogoGroup(pass, g)
+	}
+}
+
+func gogoFieldMsg(option gogoFieldOption) func(label string, uses int) string {
+	return func(label string, uses int) string {
+		return fmt.Sprintf(gogoFieldMsgFormat, label, option.name, option.becomes, gogoUsesBreak(uses))
+	}
+}
+
+// gogoMessageName returns the full proto name of the message, like `foo.Event`,
+// or its Go name when the generated file can't be read.
+func gogoMessageName(pass *analysis.Pass, files generatedFiles, named *types.Named) string {
+	f := files.of(pass.Fset, named.Obj())
+	if f == nil {
+		return named.Obj().Name()
+	}
+
+	if name, ok := f.messageFullName(named.Obj().Name()); ok {
+		return name
+	}
+
+	if name, ok := f.registeredName(named.Obj().Name()); ok {
+		return name
+	}
+
+	return named.Obj().Name()
+}
+
+func gogoUsesBreak(uses int) string {
+	if uses == 1 {
+		return "1 use breaks"
+	}
+
+	return fmt.Sprintf("%d uses break", uses)
+}
+
+// gogoFieldOptionOf returns the proto name and the gogo option of the field, if it has one.
+func gogoFieldOptionOf(named *types.Named, field *types.Var) (string, gogoFieldOption, bool) {
+	st, ok := named.Underlying().(*types.Struct)
+	if !ok {
+		return "", gogoFieldOption{}, false
+	}
+
+	var tag string
+	for i := range st.NumFields() {
+		if st.Field(i) == field {
+			tag = st.Tag(i)
+		}
+	}
+
+	name, ok := fieldProtoName(tag)
+	if !ok {
+		return "", gogoFieldOption{}, false
+	}
+
+	value, _ := reflect.StructTag(tag).Lookup("protobuf")
+	options := make(map[string]struct{})
+	for _, part := range strings.Split(value, ",") {
+		option, _, _ := strings.Cut(part, "=")
+		options[option] = struct{}{}
+	}
+
+	_, isPointer := field.Type().Underlying().(*types.Pointer)
+
+	switch {
+	case hasOption(options, "stdtime"):
+		option := gogoFieldOption{name: "stdtime", becomes: "*timestamppb.Timestamp"}
+		if !isPointer {
+			option.pkgPath, option.wrap, option.unwrap = timestamppbPkgPath, "New", "AsTime"
+		}
+		return name, option, true
+
+	case hasOption(options, "stdduration"):
+		option := gogoFieldOption{name: "stdduration", becomes: "*durationpb.Duration"}
+		if !isPointer {
+			option.pkgPath, option.wrap, option.unwrap = durationpbPkgPath, "New", "AsDuration"
+		}
+		return name, option, true
+
+	case hasOption(options, "customtype"):
+		return name, gogoFieldOption{name: "customtype", becomes: "the proto scalar type"}, true
+
+	case hasOption(options, "casttype"):
+		return name, gogoFieldOption{name: "casttype", becomes: field.Type().Underlying().String()}, true
+
+	case !isPointer && isGogoMessageType(field.Type()):
+		named, _ := namedType(field.Type())
+		return name, gogoFieldOption{name: "nullable=false", becomes: "*" + named.Obj().Name()}, true
+	}
+
+	return "", gogoFieldOption{}, false
+}
+
+func hasOption(set map[string]struct{}, key string) bool {
+	_, ok := set[key]
+	return ok
+}
+
+// gogoFieldUse returns the use of the field selector that breaks without gogo, with its fix:
+// a read of a `stdtime` value is converted with `AsTime()`, a write is wrapped into `timestamppb.New`,
+// and `&m.Foo` of a `nullable=false` field becomes `m.Foo`.
+func gogoFieldUse(pass *analysis.Pass, sel *ast.SelectorExpr, parent ast.Node, option gogoFieldOption) (gogoUse, bool) {
+	switch x := parent.(type) {
+	case *ast.SelectorExpr:
+		// `m.Meta.Key` compiles with a pointer too.
+		if x.X == sel && option.name == "nullable=false" {
+			return gogoUse{}, false
+		}
+
+	case *ast.UnaryExpr:
+		if x.Op == token.AND && option.name == "nullable=false" {
+			return gogoUse{
+				node: x,
+				edits: []analysis.TextEdit{
+					{
+						Pos:     x.Pos(),
+						End:     x.End(),
+						NewText: []byte(formatNode(sel)),
+					},
+				},
+			}, true
+		}
+
+		if x.Op == token.AND {
+			return gogoUse{node: x}, true
+		}
+
+	case *ast.AssignStmt:
+		for i, lhs := range x.Lhs {
+			if lhs != sel {
+				continue
+			}
+
+			if x.Tok != token.ASSIGN || len(x.Lhs) != len(x.Rhs) {
+				return gogoUse{node: sel}, true
+			}
+
+			return gogoValueUse(pass, sel, x.Rhs[i], option), true
+		}
+
+	case *ast.IncDecStmt:
+		return gogoUse{node: sel}, true
+
+	case *ast.CallExpr:
+		// `durationpb.New(e.Timeout)` already converts the field, the getter returns the same value.
+		if option.wrap != "" && len(x.Args) == 1 && x.Args[0] == sel && isGogoWrapCall(pass, x, option) {
+			return gogoUse{
+				node: x,
+				edits: []analysis.TextEdit{
+					{
+						Pos:     x.Pos(),
+						End:     x.End(),
+						NewText: []byte(fmt.Sprintf("%s.Get%s()", formatNode(sel.X), sel.Sel.Name)),
+					},
+				},
+			}, true
+		}
+	}
+
+	if option.unwrap == "" {
+		return gogoUse{node: sel}, true
+	}
+
+	replacement := fmt.Sprintf("%s.Get%s().%s()", formatNode(sel.X), sel.Sel.Name, option.unwrap)
+	return gogoUse{
+		node: sel,
+		edits: []analysis.TextEdit{
+			{
+				Pos:     sel.Pos(),
+				End:     sel.End(),
+				NewText: []byte(replacement),
+			},
+		},
+	}, true
+}
+
+// isGogoWrapCall reports whether the call is the function converting a value of the gogo field
+// into the protoc-gen-go type, like `timestamppb.New`.
+func isGogoWrapCall(pass *analysis.Pass, call *ast.CallExpr, option gogoFieldOption) bool {
+	fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+	return ok && fn.Pkg() != nil && fn.Pkg().Path() == option.pkgPath && fn.Name() == option.wrap
+}
+
+// gogoValueUse returns the use writing the value into the field, with the value converted
+// into the protoc-gen-go type when possible.
+func gogoValueUse(pass *analysis.Pass, node ast.Node, value ast.Expr, option gogoFieldOption) gogoUse {
+	use := gogoUse{node: node}
+
+	switch {
+	case option.wrap != "":
+		file := fileOf(pass, value.Pos())
+		if file == nil {
+			return use
+		}
+
+		name, _ := addImport(file, option.pkgPath)
+		use.pkgPath = option.pkgPath
+		use.edits = []analysis.TextEdit{
+			{
+				Pos:     value.Pos(),
+				End:     value.Pos(),
+				NewText: []byte(name + "." + option.wrap + "("),
+			},
+			{
+				Pos:     value.End(),
+				End:     value.End(),
+				NewText: []byte(")"),
+			},
+		}
+
+	case option.name == "nullable=false":
+		if _, ok := ast.Unparen(value).(*ast.CompositeLit); ok {
+			use.edits = []analysis.TextEdit{
+				{
+					Pos:     value.Pos(),
+					End:     value.Pos(),
+					NewText: []byte("&"),
+				},
+			}
+		}
+	}
+
+	return use
+}
+
+// gogoEqualUse checks that the selector is the `Equal` or `VerboseEqual` method called with one argument
+// and returns the use, fixed with `proto.Equal` for `Equal`, and the suggested replacement.
+func gogoEqualUse(pass *analysis.Pass, sel *ast.SelectorExpr, parent ast.Node) (gogoUse, string, bool) {
+	switch sel.Sel.Name {
+	case "Equal", "VerboseEqual":
+	default:
+		return gogoUse{}, "", false
+	}
+
+	call, ok := parent.(*ast.CallExpr)
+	if !ok || call.Fun != sel || len(call.Args) != 1 {
+		return gogoUse{node: sel}, "proto.Equal", true
+	}
+
+	if sel.Sel.Name == "VerboseEqual" {
+		return gogoUse{node: call}, "cmp.Diff with protocmp.Transform()", true
+	}
+
+	file := fileOf(pass, call.Pos())
+	if file == nil {
+		return gogoUse{node: call}, "proto.Equal", true
+	}
+
+	name, _ := addImport(file, protoPkgPath)
+	return gogoUse{
+		node:    call,
+		pkgPath: protoPkgPath,
+		edits: []analysis.TextEdit{
+			{
+				Pos:     call.Pos(),
+				End:     call.End(),
+				NewText: []byte(fmt.Sprintf("%s.Equal(%s, %s)", name, formatNode(sel.X), formatNode(call.Args[0]))),
+			},
+		},
+	}, "proto.Equal", true
+}
+
+// reportGogoGroup reports the uses of the group at the first one, with the fixes of all the fixable uses.
+func reportGogoGroup(pass *analysis.Pass, g *gogoGroup) {
+	sort.Slice(g.uses, func(i, j int) bool {
+		return g.uses[i].node.Pos() < g.uses[j].node.Pos()
+	})
+
+	msg := g.msg(len(g.uses))
+	diag := analysis.Diagnostic{
+		Pos:      g.uses[0].node.Pos(),
+		End:      g.uses[0].node.End(),
+		Category: "gogo-migration",
+		Message:  msg,
+	}
+
+	var edits []analysis.TextEdit
+	imported := make(map[*ast.File]struct{})
+	for _, use := range g.uses {
+		diag.Related = append(diag.Related, analysis.RelatedInformation{
+			Pos:     use.node.Pos(),
+			End:     use.node.End(),
+			Message: "used here",
+		})
+
+		edits = append(edits, use.edits...)
+
+		if use.pkgPath == "" {
+			continue
+		}
+
+		file := fileOf(pass, use.node.Pos())
+		if _, ok := imported[file]; ok || file == nil {
+			continue
+		}
+		imported[file] = struct{}{}
+
+		_, importEdits := addImport(file, use.pkgPath)
+		edits = append(edits, importEdits...)
+	}
+
+	if len(edits) > 0 {
+		diag.SuggestedFixes = []analysis.SuggestedFix{
+			{
+				Message:   "move the uses of " + g.label + " to golang/protobuf, once the code is regenerated with protoc-gen-go",
+				TextEdits: edits,
+			},
+		}
+	}
+
+	pass.Report(diag)
+}
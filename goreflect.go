@@ -0,0 +1,216 @@
+package protogetter
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+const (
+	goReflectMsgFormat = "avoid %s on proto message %s, Go reflection sees the internal state, sizeCache and unknownFields fields " +
+		"and ignores oneofs, use %s.ProtoReflect() instead"
+	structCopierMsgFormat = "avoid %s with proto message %s, it copies the internal fields as a Go struct and ignores oneofs, " +
+		"use proto.Clone, proto.Merge or ProtoReflect() instead"
+)
+
+// structWalkers are the reflect.Value and reflect.Type methods walking the struct fields.
+var structWalkers = map[string]struct{}{
+	"Field":           {},
+	"FieldByIndex":    {},
+	"FieldByName":     {},
+	"FieldByNameFunc": {},
+	"NumField":        {},
+}
+
+// structCopiers maps a package path to the functions and methods copying or decoding into Go structs by reflection.
+// Methods are keyed as `Type.Method`.
+var structCopiers = map[string]map[string]struct{}{
+	"github.com/jinzhu/copier": {
+		"Copy":           {},
+		"CopyWithOption": {},
+	},
+	"github.com/mitchellh/mapstructure":   mapstructureCopiers,
+	"github.com/go-viper/mapstructure/v2": mapstructureCopiers,
+	"github.com/mohae/deepcopy": {
+		"Copy": {},
+	},
+	"github.com/huandu/go-clone": {
+		"Clone":  {},
+		"Slowly": {},
+	},
+}
+
+var mapstructureCopiers = map[string]struct{}{
+	"Decode":             {},
+	"WeakDecode":         {},
+	"DecodeMetadata":     {},
+	"WeakDecodeMetadata": {},
+	"Decoder.Decode":     {},
+}
+
+// checkGoReflection reports Go reflection applied to proto messages: walking the struct fields of
+// `reflect.ValueOf(m)` or `reflect.TypeOf(m)`, directly or through variables, `reflect.VisibleFields`,
+// and the reflection based copiers like copier or mapstructure.
+func checkGoReflection(pass *analysis.Pass, ins *inspector.Inspector) {
+	// vars are the variables holding the reflect.Value or reflect.Type of a message, mapped to the message.
+	vars := make(map[types.Object]ast.Expr)
+
+	// reported holds the messages already reported, a walk over the fields is reported once.
+	reported := make(map[token.Pos]struct{})
+
+	nodeTypes := []ast.Node{
+		(*ast.AssignStmt)(nil),
+		(*ast.ValueSpec)(nil),
+		(*ast.CallExpr)(nil),
+	}
+
+	ins.Preorder(nodeTypes, func(n ast.Node) {
+		switch x := n.(type) {
+		case *ast.AssignStmt:
+			if len(x.Lhs) != len(x.Rhs) {
+				return
+			}
+
+			for i, lhs := range x.Lhs {
+				if ident, ok := lhs.(*ast.Ident); ok {
+					addGoReflectVar(pass.TypesInfo, vars, ident, x.Rhs[i])
+				}
+			}
+
+		case *ast.ValueSpec:
+			if len(x.Names) != len(x.Values) {
+				return
+			}
+
+			for i, name := range x.Names {
+				addGoReflectVar(pass.TypesInfo, vars, name, x.Values[i])
+			}
+
+		case *ast.CallExpr:
+			fn, ok := typeutil.Callee(pass.TypesInfo, x).(*types.Func)
+			if !ok || fn.Pkg() == nil {
+				return
+			}
+
+			name := fn.Name()
+			if recv := fn.Signature().Recv(); recv != nil {
+				named, ok := namedType(recv.Type())
+				if !ok {
+					return
+				}
+				name = named.Obj().Name() + "." + name
+			}
+
+			if fn.Pkg().Path() == "reflect" {
+				var msg ast.Expr
+				switch {
+				case name == "VisibleFields" && len(x.Args) == 1:
+					msg = reflectedMessage(pass.TypesInfo, vars, x.Args[0])
+				case fn.Signature().Recv() != nil:
+					if _, ok := structWalkers[fn.Name()]; ok {
+						msg = reflectedMessage(pass.TypesInfo, vars, x.Fun.(*ast.SelectorExpr).X)
+					}
+				}
+
+				if msg == nil {
+					return
+				}
+
+				if _, ok := reported[msg.Pos()]; ok {
+					return
+				}
+				reported[msg.Pos()] = struct{}{}
+
+				pass.Report(analysis.Diagnostic{
+					Pos:      x.Pos(),
+					End:      x.End(),
+					Category: "go-reflect",
+					Message:  fmt.Sprintf(goReflectMsgFormat, "reflect."+name, formatNode(msg), formatNode(msg)),
+				})
+				return
+			}
+
+			if _, ok := structCopiers[fn.Pkg().Path()][name]; !ok {
+				return
+			}
+
+			for _, arg := range x.Args {
+				if !isReflectedProtoMessage(pass.TypesInfo.TypeOf(arg)) {
+					continue
+				}
+
+				pass.Report(analysis.Diagnostic{
+					Pos:      x.Pos(),
+					End:      x.End(),
+					Category: "go-reflect",
+					Message:  fmt.Sprintf(structCopierMsgFormat, fn.Pkg().Name()+"."+name, formatNode(arg)),
+				})
+				return
+			}
+		}
+	})
+}
+
+func addGoReflectVar(info *types.Info, vars map[types.Object]ast.Expr, ident *ast.Ident, value ast.Expr) {
+	obj := info.ObjectOf(ident)
+	if obj == nil {
+		return
+	}
+
+	if msg := reflectedMessage(info, vars, value); msg != nil {
+		vars[obj] = msg
+	}
+}
+
+// reflectedMessage returns the message of the reflect.Value or reflect.Type expression, like
+// `reflect.ValueOf(m).Elem()`, `reflect.Indirect(reflect.ValueOf(m))` or a variable holding it.
+func reflectedMessage(info *types.Info, vars map[types.Object]ast.Expr, expr ast.Expr) ast.Expr {
+	switch x := ast.Unparen(expr).(type) {
+	case *ast.Ident:
+		return vars[info.ObjectOf(x)]
+
+	case *ast.CallExpr:
+		fn, ok := typeutil.Callee(info, x).(*types.Func)
+		if !ok || fn.Pkg() == nil || fn.Pkg().Path() != "reflect" {
+			return nil
+		}
+
+		if fn.Signature().Recv() == nil {
+			switch {
+			case (fn.Name() == "ValueOf" || fn.Name() == "TypeOf") && len(x.Args) == 1 && isReflectedProtoMessage(info.TypeOf(x.Args[0])):
+				return x.Args[0]
+			case fn.Name() == "Indirect" && len(x.Args) == 1:
+				return reflectedMessage(info, vars, x.Args[0])
+			}
+			return nil
+		}
+
+		// `v.Elem()` and `v.Type()` still describe the message.
+		if (fn.Name() == "Elem" || fn.Name() == "Type") && len(x.Args) == 0 {
+			return reflectedMessage(info, vars, x.Fun.(*ast.SelectorExpr).X)
+		}
+	}
+
+	return nil
+}
+
+// isReflectedProtoMessage reports whether the type is a proto message or a pointer to it,
+// possibly behind another pointer as copiers and decoders take `&v`.
+func isReflectedProtoMessage(t types.Type) bool {
+	if t == nil {
+		return false
+	}
+
+	if ptr, ok := t.Underlying().(*types.Pointer); ok {
+		if _, ok := ptr.Elem().Underlying().(*types.Pointer); ok {
+			t = ptr.Elem()
+		}
+	}
+
+	return isProtoMessageType(t)
+}
@@ -0,0 +1,321 @@
+package protogetter
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"reflect"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+const (
+	hybridPresenceMsgFormat = "avoid comparing hybrid proto field %s with nil, use %s instead"
+	hybridClearMsgFormat    = "avoid clearing hybrid proto field %s with nil, use %s instead"
+	hybridWriteMsgFormat    = "avoid direct write to hybrid proto field %s, use %s instead"
+)
+
+// apiLevel is the Go API generated for a message, see the `api_level` feature of the editions.
+type apiLevel string
+
+const (
+	apiLevelOpen   apiLevel = "open"
+	apiLevelHybrid apiLevel = "hybrid"
+	apiLevelOpaque apiLevel = "opaque"
+)
+
+// messageAPILevel detects the API level of the message from the `protogen` tag
+// protoc-gen-go puts on the state field, like `protogen:"hybrid.v1"`.
+// Messages generated before the tag existed use the open API.
+func messageAPILevel(named *types.Named) apiLevel {
+	st, ok := named.Underlying().(*types.Struct)
+	if !ok {
+		return apiLevelOpen
+	}
+
+	for i := range st.NumFields() {
+		if st.Field(i).Name() != "state" {
+			continue
+		}
+
+		tag, ok := reflect.StructTag(st.Tag(i)).Lookup("protogen")
+		if !ok {
+			break
+		}
+
+		level, _, _ := strings.Cut(tag, ".")
+		switch apiLevel(level) {
+		case apiLevelHybrid, apiLevelOpaque:
+			return apiLevel(level)
+		}
+	}
+
+	return apiLevelOpen
+}
+
+// hybridField checks that the expression is a field of a message generated with the hybrid API
+// and returns the message type.
+func hybridField(info *types.Info, expr ast.Expr) (*ast.SelectorExpr, *types.Named, bool) {
+	sel, ok := ast.Unparen(expr).(*ast.SelectorExpr)
+	if !ok {
+		return nil, nil, false
+	}
+
+	selection, ok := info.Selections[sel]
+	if !ok || selection.Kind() != types.FieldVal || !isProtoMessageType(selection.Recv()) {
+		return nil, nil, false
+	}
+
+	named, ok := namedType(selection.Recv())
+	if !ok || messageAPILevel(named) != apiLevelHybrid {
+		return nil, nil, false
+	}
+
+	return sel, named, true
+}
+
+// messageMethod returns the method of the message, like `HasFoo`, if it is generated.
+func messageMethod(named *types.Named, name string) (*types.Func, bool) {
+	obj, _, _ := types.LookupFieldOrMethod(types.NewPointer(named), false, named.Obj().Pkg(), name)
+	fn, ok := obj.(*types.Func)
+	return fn, ok
+}
+
+// pointerHelpers are the proto package functions returning a pointer to their argument.
+var pointerHelpers = map[string]struct{}{
+	"Bool":    {},
+	"Int32":   {},
+	"Int64":   {},
+	"Uint32":  {},
+	"Uint64":  {},
+	"Float32": {},
+	"Float64": {},
+	"String":  {},
+}
+
+// checkHybridAPI reports the direct field accesses of hybrid API messages that have an accessor,
+// which will stop compiling with the opaque API: `m.Foo != nil` instead of `m.HasFoo()`,
+// `m.Foo = nil` instead of `m.ClearFoo()` and `m.Foo = v` instead of `m.SetFoo(v)`.
+// It runs before the getter check and filters the field reads it rewrites.
+func checkHybridAPI(pass *analysis.Pass, ins *inspector.Inspector, filter *PosFilter) {
+	nodeTypes := []ast.Node{
+		(*ast.BinaryExpr)(nil),
+		(*ast.AssignStmt)(nil),
+	}
+
+	ins.Preorder(nodeTypes, func(n ast.Node) {
+		switch x := n.(type) {
+		case *ast.BinaryExpr:
+			if x.Op != token.EQL && x.Op != token.NEQ {
+				return
+			}
+
+			for _, pair := range [][2]ast.Expr{{x.X, x.Y}, {x.Y, x.X}} {
+				if !isNilIdent(pair[1]) {
+					continue
+				}
+
+				sel, named, ok := hybridField(pass.TypesInfo, pair[0])
+				if !ok {
+					continue
+				}
+
+				if _, ok := messageMethod(named, "Has"+sel.Sel.Name); !ok {
+					continue
+				}
+
+				replacement := fmt.Sprintf("%s.Has%s()", formatNode(sel.X), sel.Sel.Name)
+				if x.Op == token.EQL {
+					replacement = "!" + replacement
+				}
+
+				filter.AddPos(x.Pos())
+				filter.AddPos(sel.Pos())
+				reportHybridFix(pass, x, fmt.Sprintf(hybridPresenceMsgFormat, formatNode(sel), replacement), analysis.TextEdit{
+					Pos:     x.Pos(),
+					End:     x.End(),
+					NewText: []byte(replacement),
+				})
+				break
+			}
+
+		case *ast.AssignStmt:
+			if x.Tok != token.ASSIGN {
+				return
+			}
+
+			for i, lhs := range x.Lhs {
+				sel, named, ok := hybridField(pass.TypesInfo, lhs)
+				if !ok {
+					continue
+				}
+
+				// Only a single assignment can be turned into a call.
+				var value ast.Expr
+				if len(x.Lhs) == 1 && len(x.Rhs) == 1 {
+					value = x.Rhs[0]
+				} else if len(x.Lhs) == len(x.Rhs) {
+					value = x.Rhs[i]
+				}
+
+				reportHybridWrite(pass, x, sel, named, value)
+			}
+		}
+	})
+}
+
+// reportHybridWrite reports `m.Foo = v`, fixed with `m.ClearFoo()` for nil and `m.SetFoo(v)` otherwise.
+// The value is kept in place, so the fixes of the reads in it still apply.
+func reportHybridWrite(pass *analysis.Pass, assign *ast.AssignStmt, sel *ast.SelectorExpr, named *types.Named, value ast.Expr) {
+	recv, field := formatNode(sel.X), sel.Sel.Name
+	single := len(assign.Lhs) == 1 && value != nil
+
+	if value != nil && isNilIdent(value) {
+		if _, ok := messageMethod(named, "Clear"+field); ok {
+			replacement := fmt.Sprintf("%s.Clear%s()", recv, field)
+			msg := fmt.Sprintf(hybridClearMsgFormat, formatNode(sel), replacement)
+			if !single {
+				reportHybridFix(pass, sel, msg)
+				return
+			}
+
+			reportHybridFix(pass, assign, msg, analysis.TextEdit{
+				Pos:     assign.Pos(),
+				End:     assign.End(),
+				NewText: []byte(replacement),
+			})
+			return
+		}
+	}
+
+	setter, ok := messageMethod(named, "Set"+field)
+	if !ok {
+		return
+	}
+
+	msg := fmt.Sprintf(hybridWriteMsgFormat, formatNode(sel), fmt.Sprintf("%s.Set%s()", recv, field))
+	if !single {
+		reportHybridFix(pass, sel, msg)
+		return
+	}
+
+	param := setter.Signature().Params().At(0).Type()
+	arg, ok := setterArg(pass.TypesInfo, value, param)
+	if !ok {
+		reportHybridFix(pass, assign, msg)
+		return
+	}
+
+	edits := []analysis.TextEdit{
+		{
+			Pos:     assign.Pos(),
+			End:     arg.Pos(),
+			NewText: []byte(fmt.Sprintf("%s.Set%s(", recv, field)),
+		},
+		{
+			Pos:     arg.End(),
+			End:     assign.End(),
+			NewText: []byte(")"),
+		},
+	}
+
+	argText := formatNode(arg)
+	if edit, text, ok := selfAppendGetter(pass.TypesInfo, sel, named, arg); ok {
+		edits = append(edits, edit)
+		argText = text
+	}
+
+	msg = fmt.Sprintf(hybridWriteMsgFormat, formatNode(sel), fmt.Sprintf("%s.Set%s(%s)", recv, field, argText))
+	reportHybridFix(pass, assign, msg, edits...)
+}
+
+// selfAppendGetter checks that the setter argument appends to the written field, like `append(h.Tags, "t")`,
+// and returns the edit reading the field with its getter instead, which the setter call needs, and the new argument.
+func selfAppendGetter(info *types.Info, sel *ast.SelectorExpr, named *types.Named, arg ast.Expr) (analysis.TextEdit, string, bool) {
+	call, ok := ast.Unparen(arg).(*ast.CallExpr)
+	if !ok || len(call.Args) == 0 {
+		return analysis.TextEdit{}, "", false
+	}
+
+	fun, ok := ast.Unparen(call.Fun).(*ast.Ident)
+	if !ok || fun.Name != "append" {
+		return analysis.TextEdit{}, "", false
+	}
+
+	if _, ok := info.Uses[fun].(*types.Builtin); !ok {
+		return analysis.TextEdit{}, "", false
+	}
+
+	first, ok := ast.Unparen(call.Args[0]).(*ast.SelectorExpr)
+	if !ok || accessPath(info, first) != accessPath(info, sel) {
+		return analysis.TextEdit{}, "", false
+	}
+
+	if _, ok := messageMethod(named, "Get"+sel.Sel.Name); !ok {
+		return analysis.TextEdit{}, "", false
+	}
+
+	getter := fmt.Sprintf("%s.Get%s()", formatNode(first.X), first.Sel.Name)
+
+	replaced := *call
+	replaced.Args = append([]ast.Expr{ast.NewIdent(getter)}, call.Args[1:]...)
+
+	return analysis.TextEdit{
+		Pos:     call.Args[0].Pos(),
+		End:     call.Args[0].End(),
+		NewText: []byte(getter),
+	}, formatNode(&replaced), true
+}
+
+// setterArg returns the argument of the setter taking the param type for the assigned value:
+// the value itself if it is assignable, or `v` of the `proto.String(v)` style helpers
+// creating the pointer of an explicit presence field.
+func setterArg(info *types.Info, value ast.Expr, param types.Type) (ast.Expr, bool) {
+	if t := info.TypeOf(value); t != nil && types.AssignableTo(t, param) {
+		return value, true
+	}
+
+	call, ok := ast.Unparen(value).(*ast.CallExpr)
+	if !ok || len(call.Args) != 1 {
+		return nil, false
+	}
+
+	fn, ok := typeutil.Callee(info, call).(*types.Func)
+	if !ok || fn.Pkg() == nil || (fn.Pkg().Path() != protoPkgPath && fn.Pkg().Path() != "github.com/golang/protobuf/proto") {
+		return nil, false
+	}
+
+	if _, ok := pointerHelpers[fn.Name()]; !ok {
+		return nil, false
+	}
+
+	if t := info.TypeOf(call.Args[0]); t == nil || !types.AssignableTo(t, param) {
+		return nil, false
+	}
+
+	return call.Args[0], true
+}
+
+func reportHybridFix(pass *analysis.Pass, node ast.Node, msg string, edits ...analysis.TextEdit) {
+	diag := analysis.Diagnostic{
+		Pos:      node.Pos(),
+		End:      node.End(),
+		Category: "hybrid-api",
+		Message:  msg,
+	}
+
+	if len(edits) > 0 {
+		diag.SuggestedFixes = []analysis.SuggestedFix{
+			{
+				Message:   msg,
+				TextEdits: edits,
+			},
+		}
+	}
+
+	pass.Report(diag)
+}
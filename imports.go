@@ -0,0 +1,83 @@
+package protogetter
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"path"
+	"strconv"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// addImport returns the name to refer to the package in the file,
+// with the edits adding the import if the file does not import it yet.
+// The package name is the last element of the path, so it is only for the standard library
+// and the google.golang.org/protobuf packages, other packages go through addNamedImport.
+func addImport(file *ast.File, pkgPath string) (string, []analysis.TextEdit) {
+	return addNamedImport(file, pkgPath, path.Base(pkgPath))
+}
+
+// addNamedImport is addImport for the package with the given name, which may differ from
+// the last element of the path, like `package foov1` in `.../foo/v1`.
+// If the name is already taken by another import, the package is imported with the `v2` suffix,
+// since the inserted packages are mostly the google.golang.org/protobuf (v2) API.
+func addNamedImport(file *ast.File, pkgPath, name string) (string, []analysis.TextEdit) {
+	taken := make(map[string]struct{})
+	for _, imp := range file.Imports {
+		impPath, _ := strconv.Unquote(imp.Path.Value)
+
+		impName := path.Base(impPath)
+		if imp.Name != nil {
+			impName = imp.Name.Name
+		}
+
+		if impPath == pkgPath && impName != "_" && impName != "." {
+			return impName, nil
+		}
+
+		taken[impName] = struct{}{}
+	}
+
+	if _, ok := taken[name]; ok {
+		name += "v2"
+	}
+
+	spec := strconv.Quote(pkgPath)
+	if name != path.Base(pkgPath) {
+		spec = name + " " + spec
+	}
+
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.IMPORT {
+			continue
+		}
+
+		if gen.Lparen.IsValid() {
+			return name, []analysis.TextEdit{
+				{
+					Pos:     gen.Rparen,
+					End:     gen.Rparen,
+					NewText: []byte("\t" + spec + "\n"),
+				},
+			}
+		}
+
+		return name, []analysis.TextEdit{
+			{
+				Pos:     gen.End(),
+				End:     gen.End(),
+				NewText: []byte(fmt.Sprintf("\nimport %s", spec)),
+			},
+		}
+	}
+
+	return name, []analysis.TextEdit{
+		{
+			Pos:     file.Name.End(),
+			End:     file.Name.End(),
+			NewText: []byte(fmt.Sprintf("\n\nimport %s", spec)),
+		},
+	}
+}
@@ -0,0 +1,353 @@
+package protogetter
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+const legacyMsgFormat = "%s is deprecated, use %s instead"
+
+const (
+	timestamppbPkgPath = "google.golang.org/protobuf/types/known/timestamppb"
+	durationpbPkgPath  = "google.golang.org/protobuf/types/known/durationpb"
+	anypbPkgPath       = "google.golang.org/protobuf/types/known/anypb"
+	protodescPkgPath   = "google.golang.org/protobuf/reflect/protodesc"
+)
+
+// legacyAPI describes a deprecated github.com/golang/protobuf API and its replacement.
+type legacyAPI struct {
+	// replacement is the google.golang.org/protobuf API to suggest.
+	replacement string
+	// pkgPath is the package imported by the fix.
+	pkgPath string
+	// rename is the name in pkgPath that replaces the selector as is, if any.
+	rename string
+	// call builds the expression replacing the whole call from the package name and the arguments, if any.
+	call func(pkg string, args []string) string
+	// results is the number of results of the legacy call replaced by a single value.
+	// The fix applies only to assignments where the other results are blank.
+	results int
+	// keep is the index of the result produced by the replacement.
+	keep int
+	// fields maps the fields of a deprecated struct to the fields of the replacement, named in the report of its literals.
+	fields map[string]string
+}
+
+// legacyAPIs maps the github.com/golang/protobuf packages to their deprecated members.
+// The v1 proto.Message has no fix, the v1 messages don't implement the v2 interface.
+var legacyAPIs = map[string]map[string]legacyAPI{
+	"github.com/golang/protobuf/proto": {
+		"Marshal":           {replacement: "proto.Marshal", pkgPath: protoPkgPath, rename: "Marshal"},
+		"Unmarshal":         {replacement: "proto.Unmarshal", pkgPath: protoPkgPath, rename: "Unmarshal"},
+		"Equal":             {replacement: "proto.Equal", pkgPath: protoPkgPath, rename: "Equal"},
+		"Clone":             {replacement: "proto.Clone", pkgPath: protoPkgPath, rename: "Clone"},
+		"Merge":             {replacement: "proto.Merge", pkgPath: protoPkgPath, rename: "Merge"},
+		"Size":              {replacement: "proto.Size", pkgPath: protoPkgPath, rename: "Size"},
+		"Reset":             {replacement: "proto.Reset", pkgPath: protoPkgPath, rename: "Reset"},
+		"Bool":              {replacement: "proto.Bool", pkgPath: protoPkgPath, rename: "Bool"},
+		"Int32":             {replacement: "proto.Int32", pkgPath: protoPkgPath, rename: "Int32"},
+		"Int64":             {replacement: "proto.Int64", pkgPath: protoPkgPath, rename: "Int64"},
+		"Uint32":            {replacement: "proto.Uint32", pkgPath: protoPkgPath, rename: "Uint32"},
+		"Uint64":            {replacement: "proto.Uint64", pkgPath: protoPkgPath, rename: "Uint64"},
+		"Float32":           {replacement: "proto.Float32", pkgPath: protoPkgPath, rename: "Float32"},
+		"Float64":           {replacement: "proto.Float64", pkgPath: protoPkgPath, rename: "Float64"},
+		"String":            {replacement: "proto.String", pkgPath: protoPkgPath, rename: "String"},
+		"Message":           {replacement: "proto.Message"},
+		"MessageName":       {replacement: "proto.MessageName"},
+		"MarshalTextString": {replacement: "prototext.Format"},
+		"CompactTextString": {replacement: "prototext.MarshalOptions"},
+		"UnmarshalText":     {replacement: "prototext.Unmarshal"},
+		"MessageType":       {replacement: "protoregistry.GlobalTypes.FindMessageByName"},
+	},
+	"github.com/golang/protobuf/ptypes": {
+		"TimestampNow":  {replacement: "timestamppb.Now", pkgPath: timestamppbPkgPath, rename: "Now"},
+		"DurationProto": {replacement: "durationpb.New", pkgPath: durationpbPkgPath, rename: "New"},
+		"MarshalAny":    {replacement: "anypb.New", pkgPath: anypbPkgPath, rename: "New"},
+		"TimestampProto": {
+			replacement: "timestamppb.New",
+			pkgPath:     timestamppbPkgPath,
+			call:        func(pkg string, args []string) string { return pkg + ".New(" + args[0] + ")" },
+			results:     2,
+		},
+		"Timestamp": {
+			replacement: "(*timestamppb.Timestamp).AsTime",
+			call:        func(_ string, args []string) string { return args[0] + ".AsTime()" },
+			results:     2,
+		},
+		"Duration": {
+			replacement: "(*durationpb.Duration).AsDuration",
+			call:        func(_ string, args []string) string { return args[0] + ".AsDuration()" },
+			results:     2,
+		},
+		"UnmarshalAny": {
+			replacement: "(*anypb.Any).UnmarshalTo",
+			call:        func(_ string, args []string) string { return args[0] + ".UnmarshalTo(" + args[1] + ")" },
+		},
+		"Is": {
+			replacement: "(*anypb.Any).MessageIs",
+			call:        func(_ string, args []string) string { return args[0] + ".MessageIs(" + args[1] + ")" },
+		},
+		"AnyMessageName":  {replacement: "(*anypb.Any).MessageName"},
+		"Empty":           {replacement: "(*anypb.Any).UnmarshalNew"},
+		"TimestampString": {replacement: "(*timestamppb.Timestamp).AsTime"},
+		"DynamicAny":      {replacement: "(*anypb.Any).UnmarshalNew"},
+	},
+	"github.com/golang/protobuf/jsonpb": {
+		// The options have other methods than the jsonpb structs, `m.Marshal(w, msg)` has no one-line replacement.
+		"Marshaler":   {replacement: "protojson.MarshalOptions", fields: jsonpbMarshalerFields},
+		"Unmarshaler": {replacement: "protojson.UnmarshalOptions", fields: jsonpbUnmarshalerFields},
+		"UnmarshalString": {
+			replacement: "protojson.Unmarshal",
+			pkgPath:     protojsonPkgPath,
+			call: func(pkg string, args []string) string {
+				return pkg + ".Unmarshal([]byte(" + args[0] + "), " + args[1] + ")"
+			},
+		},
+		"Unmarshal": {replacement: "protojson.Unmarshal"},
+	},
+	"github.com/golang/protobuf/descriptor": {
+		"ForMessage": {
+			replacement: "protodesc.ToDescriptorProto",
+			pkgPath:     protodescPkgPath,
+			call: func(pkg string, args []string) string {
+				return pkg + ".ToDescriptorProto(" + args[0] + ".ProtoReflect().Descriptor())"
+			},
+			results: 2,
+			keep:    1,
+		},
+	},
+}
+
+// legacyPackages maps the deprecated packages, whose members are aliases of the new ones, to the new packages.
+var legacyPackages = map[string]string{
+	"github.com/golang/protobuf/ptypes/any":               anypbPkgPath,
+	"github.com/golang/protobuf/ptypes/duration":          durationpbPkgPath,
+	"github.com/golang/protobuf/ptypes/empty":             "google.golang.org/protobuf/types/known/emptypb",
+	"github.com/golang/protobuf/ptypes/struct":            "google.golang.org/protobuf/types/known/structpb",
+	"github.com/golang/protobuf/ptypes/timestamp":         timestamppbPkgPath,
+	"github.com/golang/protobuf/ptypes/wrappers":          "google.golang.org/protobuf/types/known/wrapperspb",
+	"github.com/golang/protobuf/protoc-gen-go/descriptor": "google.golang.org/protobuf/types/descriptorpb",
+	"github.com/golang/protobuf/protoc-gen-go/plugin":     "google.golang.org/protobuf/types/pluginpb",
+}
+
+// jsonpbMarshalerFields maps the fields of jsonpb.Marshaler to the protojson.MarshalOptions fields.
+var jsonpbMarshalerFields = map[string]string{
+	"OrigName":     "UseProtoNames",
+	"EnumsAsInts":  "UseEnumNumbers",
+	"EmitDefaults": "EmitUnpopulated",
+	"Indent":       "Indent",
+	"AnyResolver":  "Resolver",
+}
+
+// jsonpbUnmarshalerFields maps the fields of jsonpb.Unmarshaler to the protojson.UnmarshalOptions fields.
+var jsonpbUnmarshalerFields = map[string]string{
+	"AllowUnknownFields": "DiscardUnknown",
+	"AnyResolver":        "Resolver",
+}
+
+// checkLegacyAPI reports the deprecated github.com/golang/protobuf APIs
+// and fixes them to the google.golang.org/protobuf replacements where it is mechanical.
+func checkLegacyAPI(pass *analysis.Pass, ins *inspector.Inspector) {
+	nodeTypes := []ast.Node{
+		(*ast.SelectorExpr)(nil),
+	}
+
+	ins.WithStack(nodeTypes, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+
+		sel := n.(*ast.SelectorExpr)
+		pkgIdent, ok := sel.X.(*ast.Ident)
+		if !ok {
+			return true
+		}
+
+		pkgName, ok := pass.TypesInfo.Uses[pkgIdent].(*types.PkgName)
+		if !ok {
+			return true
+		}
+
+		path := pkgName.Imported().Path()
+		if newPath, ok := legacyPackages[path]; ok {
+			reportLegacyPackage(pass, sel, newPath)
+			return true
+		}
+
+		api, ok := legacyAPIs[path][sel.Sel.Name]
+		if !ok {
+			return true
+		}
+
+		reportLegacyAPI(pass, sel, api, stack)
+		return true
+	})
+}
+
+func reportLegacyPackage(pass *analysis.Pass, sel *ast.SelectorExpr, newPath string) {
+	newName := newPath[strings.LastIndex(newPath, "/")+1:]
+	msg := fmt.Sprintf(legacyMsgFormat, formatNode(sel), newName+"."+sel.Sel.Name)
+
+	diag := analysis.Diagnostic{
+		Pos:      sel.Pos(),
+		End:      sel.End(),
+		Category: "legacy-api",
+		Message:  msg,
+	}
+
+	if file := fileOf(pass, sel.Pos()); file != nil {
+		name, edits := addImport(file, newPath)
+		diag.SuggestedFixes = []analysis.SuggestedFix{legacyFix(msg, sel.X, name, edits)}
+	}
+
+	pass.Report(diag)
+}
+
+func reportLegacyAPI(pass *analysis.Pass, sel *ast.SelectorExpr, api legacyAPI, stack []ast.Node) {
+	msg := fmt.Sprintf(legacyMsgFormat, formatNode(sel), api.replacement)
+	diag := analysis.Diagnostic{
+		Pos:      sel.Pos(),
+		End:      sel.End(),
+		Category: "legacy-api",
+		Message:  msg,
+	}
+
+	file := fileOf(pass, sel.Pos())
+	if file == nil {
+		pass.Report(diag)
+		return
+	}
+
+	var parent ast.Node
+	if len(stack) > 1 {
+		parent = stack[len(stack)-2]
+	}
+
+	call, _ := parent.(*ast.CallExpr)
+	if call != nil && call.Fun != sel {
+		call = nil
+	}
+
+	if call != nil && (call.Ellipsis.IsValid() || hasLegacyMessageArg(pass.TypesInfo, call)) {
+		pass.Report(diag)
+		return
+	}
+
+	var name string
+	var edits []analysis.TextEdit
+	if api.pkgPath != "" {
+		name, edits = addImport(file, api.pkgPath)
+	}
+
+	switch {
+	case api.fields != nil:
+		if lit, ok := parent.(*ast.CompositeLit); ok && lit.Type == sel {
+			diag.Message += legacyFieldsSuffix(lit, api.fields)
+		}
+
+	case api.rename != "":
+		diag.SuggestedFixes = []analysis.SuggestedFix{legacyFix(msg, sel, name+"."+api.rename, edits)}
+
+	case api.call != nil && call != nil:
+		args := make([]string, len(call.Args))
+		for i, arg := range call.Args {
+			args[i] = formatNode(arg)
+		}
+		text := api.call(name, args)
+
+		if api.results == 0 {
+			diag.SuggestedFixes = []analysis.SuggestedFix{legacyFix(msg, call, text, edits)}
+			break
+		}
+
+		// `ts, _ := ptypes.TimestampProto(t)` is fixed to `ts := timestamppb.New(t)`.
+		if len(stack) < 3 {
+			break
+		}
+
+		assign, ok := stack[len(stack)-3].(*ast.AssignStmt)
+		if !ok || len(assign.Rhs) != 1 || assign.Rhs[0] != call || len(assign.Lhs) != api.results {
+			break
+		}
+
+		for i, lhs := range assign.Lhs {
+			if i != api.keep && !isBlankIdent(lhs) {
+				pass.Report(diag)
+				return
+			}
+		}
+
+		text = formatNode(assign.Lhs[api.keep]) + " " + assign.Tok.String() + " " + text
+		diag.SuggestedFixes = []analysis.SuggestedFix{legacyFix(msg, assign, text, edits)}
+	}
+
+	pass.Report(diag)
+}
+
+// hasLegacyMessageArg reports whether the call passes a message that implements only
+// the v1 API, which can't be passed to the google.golang.org/protobuf functions.
+// The method set is checked, so the v1 `proto.Message` interface counts as well as the message types.
+func hasLegacyMessageArg(info *types.Info, call *ast.CallExpr) bool {
+	for _, arg := range call.Args {
+		t := info.TypeOf(arg)
+		if t == nil {
+			continue
+		}
+
+		methods := types.NewMethodSet(t)
+		if methods.Lookup(nil, "ProtoMessage") != nil && methods.Lookup(nil, "ProtoReflect") == nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// legacyFieldsSuffix names the replacements of the fields set by the literal, like `, with UseProtoNames for OrigName`.
+func legacyFieldsSuffix(lit *ast.CompositeLit, fields map[string]string) string {
+	var renames []string
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+
+		key, ok := kv.Key.(*ast.Ident)
+		if !ok {
+			continue
+		}
+
+		if newKey, ok := fields[key.Name]; ok {
+			renames = append(renames, newKey+" for "+key.Name)
+		}
+	}
+
+	if len(renames) == 0 {
+		return ""
+	}
+
+	return ", with " + strings.Join(renames, ", ")
+}
+
+func legacyFix(msg string, node ast.Node, text string, edits []analysis.TextEdit) analysis.SuggestedFix {
+	return analysis.SuggestedFix{
+		Message: msg,
+		TextEdits: append([]analysis.TextEdit{
+			{
+				Pos:     node.Pos(),
+				End:     node.End(),
+				NewText: []byte(text),
+			},
+		}, edits...),
+	}
+}
+
+func isBlankIdent(expr ast.Expr) bool {
+	ident, ok := expr.(*ast.Ident)
+	return ok && ident.Name == "_"
+}
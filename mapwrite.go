@@ -0,0 +1,229 @@
+package protogetter
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+const nilMapWriteMsgFormat = "possible write to nil proto map %s, allocate it before writing"
+
+// checkNilMapWrites reports writes to the proto map fields, like `t.Map["k"] = v` or `t.GetMap()["k"] = v`,
+// when the map is not allocated earlier in the function. Maps of a new message are nil, so such writes panic.
+func checkNilMapWrites(pass *analysis.Pass, ins *inspector.Inspector) {
+	nodeTypes := []ast.Node{
+		(*ast.AssignStmt)(nil),
+		(*ast.IncDecStmt)(nil),
+	}
+
+	ins.WithStack(nodeTypes, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+
+		var targets []ast.Expr
+		switch x := n.(type) {
+		case *ast.AssignStmt:
+			if x.Tok == token.DEFINE {
+				return true
+			}
+			targets = x.Lhs
+
+		case *ast.IncDecStmt:
+			targets = []ast.Expr{x.X}
+		}
+
+		for _, target := range targets {
+			index, ok := ast.Unparen(target).(*ast.IndexExpr)
+			if !ok {
+				continue
+			}
+
+			owner, field, ok := protoMapField(pass.TypesInfo, index.X)
+			if !ok || isMapAllocated(pass.TypesInfo, n, stack, owner, field) {
+				continue
+			}
+
+			msg := fmt.Sprintf(nilMapWriteMsgFormat, formatNode(index.X))
+			diag := analysis.Diagnostic{
+				Pos:      index.Pos(),
+				End:      index.End(),
+				Category: "nil-map-write",
+				Message:  msg,
+			}
+			if fix, ok := initMapFix(pass, n, stack, index.X, owner, field); ok {
+				diag.SuggestedFixes = []analysis.SuggestedFix{fix}
+			}
+			pass.Report(diag)
+		}
+
+		return true
+	})
+}
+
+// protoMapField checks that the expression is a map field of a proto message, `t.Map` or `t.GetMap()`,
+// and returns the message and the field name.
+func protoMapField(info *types.Info, expr ast.Expr) (ast.Expr, string, bool) {
+	t := info.TypeOf(expr)
+	if t == nil {
+		return nil, "", false
+	}
+
+	if _, ok := t.Underlying().(*types.Map); !ok {
+		return nil, "", false
+	}
+
+	switch x := ast.Unparen(expr).(type) {
+	case *ast.SelectorExpr:
+		if isProtoMessage(info, x.X) {
+			return x.X, x.Sel.Name, true
+		}
+
+	case *ast.CallExpr:
+		fun, ok := x.Fun.(*ast.SelectorExpr)
+		if ok && len(x.Args) == 0 && strings.HasPrefix(fun.Sel.Name, "Get") && isProtoMessage(info, fun.X) {
+			return fun.X, strings.TrimPrefix(fun.Sel.Name, "Get"), true
+		}
+	}
+
+	return nil, "", false
+}
+
+// isMapAllocated reports whether the map field is known to be allocated before the statement:
+// it was assigned, set with a setter, written before (which is reported instead),
+// or the message was created with a literal that sets the map.
+func isMapAllocated(info *types.Info, stmt ast.Node, stack []ast.Node, owner ast.Expr, field string) bool {
+	body := enclosingFuncBody(stack)
+	if body == nil {
+		return false
+	}
+
+	ownerPath := accessPath(info, owner)
+	fieldPath := ownerPath + "." + field
+
+	allocated := false
+	ast.Inspect(body, func(n ast.Node) bool {
+		if allocated || n == nil || n.Pos() >= stmt.Pos() {
+			return false
+		}
+
+		switch x := n.(type) {
+		case *ast.AssignStmt:
+			for i, lhs := range x.Lhs {
+				var rhs ast.Expr
+				if len(x.Lhs) == len(x.Rhs) {
+					rhs = x.Rhs[i]
+				}
+
+				if rhs != nil && isNilIdent(rhs) {
+					continue
+				}
+
+				switch path := accessPath(info, ast.Unparen(lhs)); {
+				case path == fieldPath:
+					allocated = true
+				case strings.HasPrefix(path, fieldPath+"["):
+					// The previous write is reported, no need to report every write.
+					allocated = true
+				case path == ownerPath && rhs != nil && literalSetsField(rhs, field):
+					allocated = true
+				}
+			}
+
+		case *ast.CallExpr:
+			fun, ok := x.Fun.(*ast.SelectorExpr)
+			if ok && fun.Sel.Name == "Set"+field && accessPath(info, fun.X) == ownerPath {
+				allocated = true
+			}
+		}
+
+		return true
+	})
+
+	return allocated
+}
+
+// literalSetsField reports whether the expression is `&T{Field: v}` or `T{Field: v}` with a non-nil value.
+func literalSetsField(expr ast.Expr, field string) bool {
+	if u, ok := expr.(*ast.UnaryExpr); ok && u.Op == token.AND {
+		expr = u.X
+	}
+
+	lit, ok := expr.(*ast.CompositeLit)
+	if !ok {
+		return false
+	}
+
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+
+		if key, ok := kv.Key.(*ast.Ident); ok && key.Name == field && !isNilIdent(kv.Value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// initMapFix inserts `if t.Map == nil { t.Map = map[K]V{} }` before the statement,
+// or `if t.GetMap() == nil { t.SetMap(map[K]V{}) }` for messages without the exported field.
+func initMapFix(pass *analysis.Pass, stmt ast.Node, stack []ast.Node, mapExpr, owner ast.Expr, field string) (analysis.SuggestedFix, bool) {
+	if len(stack) < 2 || !isPureExpr(owner) {
+		return analysis.SuggestedFix{}, false
+	}
+
+	switch stack[len(stack)-2].(type) {
+	case *ast.BlockStmt, *ast.CaseClause, *ast.CommClause:
+	default:
+		return analysis.SuggestedFix{}, false
+	}
+
+	file := fileOf(pass, stmt.Pos())
+	if file == nil {
+		return analysis.SuggestedFix{}, false
+	}
+
+	mapType, ok := qualifiedTypeString(pass.Pkg, file, pass.TypesInfo.TypeOf(mapExpr))
+	if !ok {
+		return analysis.SuggestedFix{}, false
+	}
+
+	named, ok := namedType(pass.TypesInfo.TypeOf(owner))
+	if !ok {
+		return analysis.SuggestedFix{}, false
+	}
+
+	indent := indentOf(pass.Fset, stmt.Pos())
+	ownerName := formatNode(owner)
+
+	var text string
+	obj, _, _ := types.LookupFieldOrMethod(named, true, pass.Pkg, field)
+	if v, ok := obj.(*types.Var); ok && v.IsField() {
+		name := ownerName + "." + field
+		text = fmt.Sprintf("if %s == nil {\n%s\t%s = %s{}\n%s}\n%s", name, indent, name, mapType, indent, indent)
+	} else if namedHasMethod(named, "Set"+field) && namedHasMethod(named, "Get"+field) {
+		text = fmt.Sprintf("if %s.Get%s() == nil {\n%s\t%s.Set%s(%s{})\n%s}\n%s",
+			ownerName, field, indent, ownerName, field, mapType, indent, indent)
+	} else {
+		return analysis.SuggestedFix{}, false
+	}
+
+	return analysis.SuggestedFix{
+		Message: "allocate the map before writing",
+		TextEdits: []analysis.TextEdit{
+			{
+				Pos:     stmt.Pos(),
+				End:     stmt.Pos(),
+				NewText: []byte(text),
+			},
+		},
+	}, true
+}
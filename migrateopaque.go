@@ -0,0 +1,260 @@
+package protogetter
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+const (
+	migrateLiteralMsgFormat     = "avoid composite literal of hybrid proto message %s, use %s{...}.Build() instead"
+	migrateUnconvertedMsgFormat = "cannot migrate %s to the opaque API automatically, %s"
+)
+
+// reportedRanges records the reported diagnostics,
+// so the migration knows which field accesses are already converted or reported.
+type reportedRanges []analysis.Diagnostic
+
+// covers reports whether some fix rewrites the node or some diagnostic without a fix spans it.
+func (r reportedRanges) covers(n ast.Node) bool {
+	contains := func(pos, end token.Pos) bool {
+		if !end.IsValid() {
+			end = pos
+		}
+		return pos <= n.Pos() && n.End() <= end
+	}
+
+	for _, diag := range r {
+		if len(diag.SuggestedFixes) == 0 {
+			if contains(diag.Pos, diag.End) {
+				return true
+			}
+			continue
+		}
+
+		for _, edit := range diag.SuggestedFixes[0].TextEdits {
+			if contains(edit.Pos, edit.End) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// trackReports wraps the reporter of the pass to record the reported diagnostics.
+// The returned function restores the original reporter.
+func trackReports(pass *analysis.Pass, reported *reportedRanges) func() {
+	report := pass.Report
+	pass.Report = func(diag analysis.Diagnostic) {
+		*reported = append(*reported, diag)
+		report(diag)
+	}
+
+	return func() {
+		pass.Report = report
+	}
+}
+
+// migrateOpaqueLiterals rewrites the composite literals of hybrid API messages,
+// like `&pb.Foo{Bar: 1}`, into the builders `pb.Foo_builder{Bar: 1}.Build()`, which work with the opaque API.
+// The literals whose fields have no builder counterpart, like oneof wrappers, are reported without a fix.
+func migrateOpaqueLiterals(pass *analysis.Pass, ins *inspector.Inspector) {
+	ins.WithStack([]ast.Node{(*ast.CompositeLit)(nil)}, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+
+		lit := n.(*ast.CompositeLit)
+		if len(lit.Elts) == 0 {
+			// An empty literal compiles with the opaque API too.
+			return true
+		}
+
+		named, ok := namedType(pass.TypesInfo.TypeOf(lit))
+		if !ok || !isProtoMessageType(named) || messageAPILevel(named) != apiLevelHybrid {
+			return true
+		}
+
+		// The literal is replaced from `&` or, for an elided `&T` in a slice or a map literal, from the brace.
+		var start token.Pos
+		switch parent := stack[len(stack)-2].(type) {
+		case *ast.UnaryExpr:
+			if parent.Op == token.AND {
+				start = parent.Pos()
+			}
+
+		default:
+			if lit.Type == nil && elidedPointer(pass.TypesInfo, stack) {
+				start = lit.Pos()
+			}
+		}
+
+		what := "literal of " + named.Obj().Name()
+		if !start.IsValid() {
+			reportUnmigrated(pass, lit, what, "the builder creates a pointer, not a message value")
+			return true
+		}
+
+		file := fileOf(pass, lit.Pos())
+		if file == nil {
+			return true
+		}
+
+		name, ok := qualifiedTypeString(pass.Pkg, file, named)
+		if !ok {
+			return true
+		}
+
+		builder, ok := messageBuilder(named)
+		if !ok {
+			return true
+		}
+
+		for _, elt := range lit.Elts {
+			kv, ok := elt.(*ast.KeyValueExpr)
+			if !ok {
+				reportUnmigrated(pass, lit, what, "the builder has no unkeyed literals")
+				return true
+			}
+
+			key := kv.Key.(*ast.Ident)
+			field := builderField(builder, key.Name)
+			if field == nil {
+				reportUnmigrated(pass, lit, what, fmt.Sprintf("the builder has no field %s", key.Name))
+				return true
+			}
+
+			if t := pass.TypesInfo.TypeOf(kv.Value); t == nil || !types.AssignableTo(t, field.Type()) {
+				reportUnmigrated(pass, lit, what, fmt.Sprintf("the value of %s does not match the builder field", key.Name))
+				return true
+			}
+		}
+
+		msg := fmt.Sprintf(migrateLiteralMsgFormat, name, name+"_builder")
+		pass.Report(analysis.Diagnostic{
+			Pos:      start,
+			End:      lit.End(),
+			Category: "migrate-opaque",
+			Message:  msg,
+			SuggestedFixes: []analysis.SuggestedFix{
+				{
+					Message: msg,
+					TextEdits: []analysis.TextEdit{
+						{
+							Pos:     start,
+							End:     lit.Lbrace,
+							NewText: []byte(name + "_builder"),
+						},
+						{
+							Pos:     lit.End(),
+							End:     lit.End(),
+							NewText: []byte(".Build()"),
+						},
+					},
+				},
+			},
+		})
+
+		return true
+	})
+}
+
+// elidedPointer reports whether the literal on top of the stack is an element of a slice, an array or a map literal
+// whose type is a pointer, like `[]*pb.Foo{{Bar: 1}}`.
+func elidedPointer(info *types.Info, stack []ast.Node) bool {
+	lit := stack[len(stack)-1]
+	i := len(stack) - 2
+
+	isKey := false
+	if kv, ok := stack[i].(*ast.KeyValueExpr); ok {
+		isKey = kv.Key == lit
+		i--
+	}
+
+	outer, ok := stack[i].(*ast.CompositeLit)
+	if !ok {
+		return false
+	}
+
+	t := info.TypeOf(outer)
+	if t == nil {
+		return false
+	}
+
+	if ptr, ok := t.Underlying().(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+
+	var elem types.Type
+	switch u := t.Underlying().(type) {
+	case *types.Slice:
+		elem = u.Elem()
+	case *types.Array:
+		elem = u.Elem()
+	case *types.Map:
+		elem = u.Elem()
+		if isKey {
+			elem = u.Key()
+		}
+	default:
+		return false
+	}
+
+	_, ok = elem.Underlying().(*types.Pointer)
+	return ok
+}
+
+// messageBuilder returns the `Foo_builder` struct generated next to the message.
+func messageBuilder(named *types.Named) (*types.Struct, bool) {
+	obj, ok := named.Obj().Pkg().Scope().Lookup(named.Obj().Name() + "_builder").(*types.TypeName)
+	if !ok {
+		return nil, false
+	}
+
+	st, ok := obj.Type().Underlying().(*types.Struct)
+	return st, ok
+}
+
+func builderField(builder *types.Struct, name string) *types.Var {
+	for i := range builder.NumFields() {
+		if f := builder.Field(i); f.Exported() && f.Name() == name {
+			return f
+		}
+	}
+
+	return nil
+}
+
+// reportUnmigratedFields reports the direct accesses of hybrid message fields
+// that no other diagnostic converted or reported, like `&m.Foo` or `m.Foo++`.
+func reportUnmigratedFields(pass *analysis.Pass, ins *inspector.Inspector, reported reportedRanges) {
+	ins.Preorder([]ast.Node{(*ast.SelectorExpr)(nil)}, func(n ast.Node) {
+		sel, _, ok := hybridField(pass.TypesInfo, n.(*ast.SelectorExpr))
+		if !ok || reported.covers(sel) {
+			return
+		}
+
+		reason := "the field has no accessor for this use"
+		if named, ok := typesNamed(pass.TypesInfo, sel.X); ok {
+			if _, ok := messageMethod(named, "Get"+sel.Sel.Name); ok {
+				reason = fmt.Sprintf("only the value of the field is accessible, with %s.Get%s()", formatNode(sel.X), sel.Sel.Name)
+			}
+		}
+
+		reportUnmigrated(pass, sel, "direct access to "+formatNode(sel), reason)
+	})
+}
+
+func reportUnmigrated(pass *analysis.Pass, node ast.Node, what, reason string) {
+	pass.Report(analysis.Diagnostic{
+		Pos:      node.Pos(),
+		End:      node.End(),
+		Category: "migrate-opaque",
+		Message:  fmt.Sprintf(migrateUnconvertedMsgFormat, what, reason),
+	})
+}
@@ -0,0 +1,330 @@
+package protogetter
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+const nestedWriteMsgFormat = "avoid writing to proto field %s through possibly nil message %s"
+
+func checkNestedWrites(pass *analysis.Pass, ins *inspector.Inspector) {
+	nodeTypes := []ast.Node{
+		(*ast.AssignStmt)(nil),
+		(*ast.IncDecStmt)(nil),
+	}
+
+	ins.WithStack(nodeTypes, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+
+		var targets []ast.Expr
+		switch x := n.(type) {
+		case *ast.AssignStmt:
+			if x.Tok == token.DEFINE {
+				return true
+			}
+			targets = x.Lhs
+
+		case *ast.IncDecStmt:
+			targets = []ast.Expr{x.X}
+		}
+
+		for _, target := range targets {
+			target, parents := nestedWriteParents(pass.TypesInfo, target)
+			if len(parents) == 0 {
+				continue
+			}
+
+			var unguarded []*ast.SelectorExpr
+			for _, parent := range parents {
+				if !isNilGuarded(pass.TypesInfo, n, stack, parent) {
+					unguarded = append(unguarded, parent)
+				}
+			}
+			if len(unguarded) == 0 {
+				continue
+			}
+
+			msg := fmt.Sprintf(nestedWriteMsgFormat, formatNode(target), formatNode(unguarded[0]))
+			diag := analysis.Diagnostic{
+				Pos:      target.Pos(),
+				End:      target.End(),
+				Category: "nested-write",
+				Message:  msg,
+			}
+			if fix, ok := initParentsFix(pass, n, stack, unguarded); ok {
+				diag.SuggestedFixes = []analysis.SuggestedFix{fix}
+			}
+			pass.Report(diag)
+
+			// One report per statement is enough, the fix initializes all parents at once.
+			break
+		}
+
+		return true
+	})
+}
+
+// nestedWriteParents returns the written proto field selector and all intermediate
+// proto fields in its chain that hold a message pointer, ordered from the outermost one.
+func nestedWriteParents(info *types.Info, expr ast.Expr) (*ast.SelectorExpr, []*ast.SelectorExpr) {
+	for {
+		switch x := expr.(type) {
+		case *ast.ParenExpr:
+			expr = x.X
+			continue
+		case *ast.StarExpr:
+			expr = x.X
+			continue
+		case *ast.IndexExpr:
+			expr = x.X
+			continue
+		}
+		break
+	}
+
+	target, ok := expr.(*ast.SelectorExpr)
+	if !ok || !isProtoMessage(info, target.X) {
+		return nil, nil
+	}
+
+	var parents []*ast.SelectorExpr
+	expr = target.X
+	for {
+		if p, ok := expr.(*ast.ParenExpr); ok {
+			expr = p.X
+			continue
+		}
+
+		se, ok := expr.(*ast.SelectorExpr)
+		if !ok {
+			break
+		}
+
+		if isProtoMessage(info, se.X) && isProtoMessagePointer(info.TypeOf(se)) {
+			parents = append(parents, se)
+		}
+		expr = se.X
+	}
+
+	// Reverse to get the outermost parent first.
+	for i, j := 0, len(parents)-1; i < j; i, j = i+1, j-1 {
+		parents[i], parents[j] = parents[j], parents[i]
+	}
+
+	return target, parents
+}
+
+// isNilGuarded reports whether the parent is known to be non-nil at the statement:
+// it is checked by an enclosing if, or it was assigned or checked with an early return before.
+func isNilGuarded(info *types.Info, stmt ast.Node, stack []ast.Node, parent ast.Expr) bool {
+	name := accessPath(info, parent)
+
+	for i := len(stack) - 2; i >= 0; i-- {
+		ifStmt, ok := stack[i].(*ast.IfStmt)
+		if !ok || stack[i+1] != ifStmt.Body {
+			continue
+		}
+
+		if hasNilComparison(info, ifStmt.Cond, name, token.NEQ) {
+			return true
+		}
+	}
+
+	body := enclosingFuncBody(stack)
+	if body == nil {
+		return false
+	}
+
+	guarded := false
+	ast.Inspect(body, func(n ast.Node) bool {
+		if guarded || n == nil || n.Pos() >= stmt.Pos() {
+			return false
+		}
+
+		switch x := n.(type) {
+		case *ast.AssignStmt:
+			for i, lhs := range x.Lhs {
+				if len(x.Lhs) == len(x.Rhs) && isNilIdent(x.Rhs[i]) {
+					continue
+				}
+
+				if accessPath(info, lhs) == name {
+					guarded = true
+				}
+			}
+
+		case *ast.IfStmt:
+			if x.End() < stmt.Pos() && hasNilComparison(info, x.Cond, name, token.EQL) && isTerminating(x.Body) {
+				guarded = true
+			}
+		}
+
+		return true
+	})
+
+	return guarded
+}
+
+// hasNilComparison reports whether cond is `name <op> nil` or a && chain containing it.
+func hasNilComparison(info *types.Info, cond ast.Expr, name string, op token.Token) bool {
+	switch x := cond.(type) {
+	case *ast.ParenExpr:
+		return hasNilComparison(info, x.X, name, op)
+
+	case *ast.BinaryExpr:
+		if x.Op == token.LAND {
+			return hasNilComparison(info, x.X, name, op) || hasNilComparison(info, x.Y, name, op)
+		}
+
+		if x.Op != op {
+			return false
+		}
+
+		if isNilIdent(x.Y) {
+			return accessPath(info, x.X) == name
+		}
+		if isNilIdent(x.X) {
+			return accessPath(info, x.Y) == name
+		}
+	}
+
+	return false
+}
+
+// accessPath returns the field path of the expression, where getter calls are written as fields,
+// so `t.GetEmbedded().Embedded` and `t.Embedded.Embedded` have the same path.
+func accessPath(info *types.Info, expr ast.Expr) string {
+	switch x := expr.(type) {
+	case *ast.ParenExpr:
+		return accessPath(info, x.X)
+
+	case *ast.SelectorExpr:
+		return accessPath(info, x.X) + "." + x.Sel.Name
+
+	case *ast.IndexExpr:
+		return accessPath(info, x.X) + "[" + formatNode(x.Index) + "]"
+
+	case *ast.CallExpr:
+		fun, ok := x.Fun.(*ast.SelectorExpr)
+		if ok && len(x.Args) == 0 && strings.HasPrefix(fun.Sel.Name, "Get") && isProtoMessage(info, fun.X) {
+			return accessPath(info, fun.X) + "." + strings.TrimPrefix(fun.Sel.Name, "Get")
+		}
+	}
+
+	return formatNode(expr)
+}
+
+func isNilIdent(expr ast.Expr) bool {
+	ident, ok := expr.(*ast.Ident)
+	return ok && ident.Name == "nil"
+}
+
+func isTerminating(body *ast.BlockStmt) bool {
+	if len(body.List) == 0 {
+		return false
+	}
+
+	switch x := body.List[len(body.List)-1].(type) {
+	case *ast.ReturnStmt, *ast.BranchStmt:
+		return true
+	case *ast.ExprStmt:
+		call, ok := x.X.(*ast.CallExpr)
+		if !ok {
+			return false
+		}
+		ident, ok := call.Fun.(*ast.Ident)
+		return ok && ident.Name == "panic"
+	}
+
+	return false
+}
+
+func enclosingFuncBody(stack []ast.Node) *ast.BlockStmt {
+	for i := len(stack) - 1; i >= 0; i-- {
+		switch x := stack[i].(type) {
+		case *ast.FuncDecl:
+			return x.Body
+		case *ast.FuncLit:
+			return x.Body
+		}
+	}
+
+	return nil
+}
+
+// initParentsFix inserts `if p == nil { p = &T{} }` before the statement for every parent.
+// The fix is only suggested when the statement is a plain statement of a block
+// and the parents can be evaluated again without side effects.
+func initParentsFix(pass *analysis.Pass, stmt ast.Node, stack []ast.Node, parents []*ast.SelectorExpr) (analysis.SuggestedFix, bool) {
+	if len(stack) < 2 {
+		return analysis.SuggestedFix{}, false
+	}
+
+	switch stack[len(stack)-2].(type) {
+	case *ast.BlockStmt, *ast.CaseClause, *ast.CommClause:
+	default:
+		return analysis.SuggestedFix{}, false
+	}
+
+	file := fileOf(pass, stmt.Pos())
+	if file == nil {
+		return analysis.SuggestedFix{}, false
+	}
+
+	indent := indentOf(pass.Fset, stmt.Pos())
+
+	var b strings.Builder
+	for _, parent := range parents {
+		if !isPureExpr(parent) {
+			return analysis.SuggestedFix{}, false
+		}
+
+		ptr, ok := pass.TypesInfo.TypeOf(parent).(*types.Pointer)
+		if !ok {
+			return analysis.SuggestedFix{}, false
+		}
+
+		typeName, ok := qualifiedTypeString(pass.Pkg, file, ptr.Elem())
+		if !ok {
+			return analysis.SuggestedFix{}, false
+		}
+
+		name := formatNode(parent)
+		fmt.Fprintf(&b, "if %s == nil {\n%s\t%s = &%s{}\n%s}\n%s", name, indent, name, typeName, indent, indent)
+	}
+
+	return analysis.SuggestedFix{
+		Message: "initialize parent messages before writing",
+		TextEdits: []analysis.TextEdit{
+			{
+				Pos:     stmt.Pos(),
+				End:     stmt.Pos(),
+				NewText: []byte(b.String()),
+			},
+		},
+	}, true
+}
+
+// isPureExpr reports whether the expression can be evaluated repeatedly without side effects.
+func isPureExpr(expr ast.Expr) bool {
+	switch x := expr.(type) {
+	case *ast.Ident, *ast.BasicLit:
+		return true
+	case *ast.SelectorExpr:
+		return isPureExpr(x.X)
+	case *ast.ParenExpr:
+		return isPureExpr(x.X)
+	case *ast.IndexExpr:
+		return isPureExpr(x.X) && isPureExpr(x.Index)
+	}
+
+	return false
+}
@@ -0,0 +1,300 @@
+package protogetter
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"reflect"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// oneofAssertParent checks that the selector reads a field of a oneof wrapper type asserted
+// from a oneof field, like `m.Kind.(*pb.Msg_Foo).Foo` or `m.GetKind().(*pb.Msg_Foo).Foo`,
+// and returns the message containing the oneof.
+func oneofAssertParent(info *types.Info, sel *ast.SelectorExpr) (ast.Expr, bool) {
+	ta, ok := sel.X.(*ast.TypeAssertExpr)
+	if !ok || ta.Type == nil {
+		return nil, false
+	}
+
+	return oneofWrapperParent(info, ta, sel.Sel.Name)
+}
+
+// oneofWrapperParent checks that the type assertion converts a oneof field to the wrapper
+// containing the given field and the parent message has a getter for it.
+func oneofWrapperParent(info *types.Info, ta *ast.TypeAssertExpr, field string) (ast.Expr, bool) {
+	parent, iface, ok := oneofSource(info, ta.X)
+	if !ok {
+		return nil, false
+	}
+
+	wrapper := info.TypeOf(ta.Type)
+	if !isOneofWrapper(wrapper, field) || !types.Implements(wrapper, iface) {
+		return nil, false
+	}
+
+	if !methodIsExists(info, parent, "Get"+field) {
+		return nil, false
+	}
+
+	return parent, true
+}
+
+// oneofSource checks that the expression reads a oneof field, directly or with a getter,
+// and returns the message containing it and the generated oneof interface.
+func oneofSource(info *types.Info, expr ast.Expr) (ast.Expr, *types.Interface, bool) {
+	var parent ast.Expr
+	switch x := expr.(type) {
+	case *ast.SelectorExpr:
+		parent = x.X
+
+	case *ast.CallExpr:
+		fun, ok := x.Fun.(*ast.SelectorExpr)
+		if !ok || len(x.Args) != 0 || !strings.HasPrefix(fun.Sel.Name, "Get") {
+			return nil, nil, false
+		}
+		parent = fun.X
+
+	default:
+		return nil, nil, false
+	}
+
+	if !isProtoMessage(info, parent) {
+		return nil, nil, false
+	}
+
+	iface, ok := oneofInterface(info.TypeOf(expr))
+	if !ok {
+		return nil, nil, false
+	}
+
+	return parent, iface, true
+}
+
+// oneofInterface checks that the type is a generated oneof interface, like `isMsg_Kind`.
+func oneofInterface(t types.Type) (*types.Interface, bool) {
+	named, ok := t.(*types.Named)
+	if !ok || !strings.HasPrefix(named.Obj().Name(), "is") {
+		return nil, false
+	}
+
+	iface, ok := named.Underlying().(*types.Interface)
+	if !ok || iface.NumMethods() != 1 || iface.Method(0).Name() != named.Obj().Name() {
+		return nil, false
+	}
+
+	return iface, true
+}
+
+// isOneofWrapper checks that the type is a generated oneof wrapper, like `*Msg_Foo`,
+// with the given field. An empty field matches any wrapper.
+func isOneofWrapper(t types.Type, field string) bool {
+	ptr, ok := t.(*types.Pointer)
+	if !ok {
+		return false
+	}
+
+	st, ok := ptr.Elem().Underlying().(*types.Struct)
+	if !ok || st.NumFields() != 1 {
+		return false
+	}
+
+	if field != "" && st.Field(0).Name() != field {
+		return false
+	}
+
+	tag, ok := reflect.StructTag(st.Tag(0)).Lookup("protobuf")
+	return ok && strings.HasSuffix(tag, ",oneof")
+}
+
+type oneofVar struct {
+	parent ast.Expr
+	ok     types.Object
+}
+
+// checkOneofWrapperReads reports reads of the wrapper field from a variable
+// that was asserted from a oneof field without checking the result of the assertion:
+//
+//	v, _ := m.GetKind().(*pb.Msg_Foo)
+//	_ = v.Foo // use m.GetFoo() instead
+func checkOneofWrapperReads(pass *analysis.Pass, ins *inspector.Inspector, filter *PosFilter) {
+	vars := make(map[types.Object]oneofVar)
+
+	ins.Preorder([]ast.Node{(*ast.AssignStmt)(nil)}, func(n ast.Node) {
+		assign := n.(*ast.AssignStmt)
+		if len(assign.Lhs) != 2 || len(assign.Rhs) != 1 {
+			return
+		}
+
+		ta, ok := assign.Rhs[0].(*ast.TypeAssertExpr)
+		if !ok || ta.Type == nil {
+			return
+		}
+
+		parent, _, ok := oneofSource(pass.TypesInfo, ta.X)
+		if !ok || !isOneofWrapper(pass.TypesInfo.TypeOf(ta.Type), "") || !isPureExpr(parent) {
+			return
+		}
+
+		ident, ok := assign.Lhs[0].(*ast.Ident)
+		if !ok {
+			return
+		}
+
+		obj := pass.TypesInfo.ObjectOf(ident)
+		if obj == nil {
+			return
+		}
+
+		var okObj types.Object
+		if okIdent, ok := assign.Lhs[1].(*ast.Ident); ok {
+			okObj = pass.TypesInfo.ObjectOf(okIdent)
+		}
+
+		vars[obj] = oneofVar{parent: parent, ok: okObj}
+	})
+
+	if len(vars) == 0 {
+		return
+	}
+
+	// The reads are fixed only when the variable has other uses,
+	// otherwise the fixed code does not compile: `declared and not used`.
+	uses := make(map[types.Object]int)
+	for _, obj := range pass.TypesInfo.Uses {
+		if _, ok := vars[obj]; ok {
+			uses[obj]++
+		}
+	}
+
+	reads := make(map[types.Object][]analysis.Diagnostic)
+	var order []types.Object
+
+	ins.WithStack([]ast.Node{(*ast.SelectorExpr)(nil)}, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+
+		sel := n.(*ast.SelectorExpr)
+		ident, ok := sel.X.(*ast.Ident)
+		if !ok {
+			return true
+		}
+
+		obj := pass.TypesInfo.ObjectOf(ident)
+		v, ok := vars[obj]
+		if !ok || !methodIsExists(pass.TypesInfo, v.parent, "Get"+sel.Sel.Name) {
+			return true
+		}
+
+		if isOneofVarGuarded(pass.TypesInfo, n, stack, ident, v) {
+			return true
+		}
+
+		// Writes to the wrapper are not reads.
+		if assign, ok := stack[len(stack)-2].(*ast.AssignStmt); ok {
+			for _, lhs := range assign.Lhs {
+				if lhs == sel {
+					return true
+				}
+			}
+		}
+
+		if filter.IsAlreadyReplaced(pass.Fset, sel.Pos(), sel.End()) {
+			return true
+		}
+		filter.AddAlreadyReplaced(pass.Fset, sel.Pos(), sel.End())
+
+		report := &Report{
+			node: sel,
+			result: &Result{
+				From: formatNode(sel),
+				To:   formatNode(v.parent) + ".Get" + sel.Sel.Name + "()",
+			},
+		}
+		if _, ok := reads[obj]; !ok {
+			order = append(order, obj)
+		}
+		reads[obj] = append(reads[obj], report.ToDiagReport())
+
+		return true
+	})
+
+	for _, obj := range order {
+		fixable := uses[obj] > len(reads[obj])
+		for _, diag := range reads[obj] {
+			if !fixable {
+				diag.SuggestedFixes = nil
+			}
+			pass.Report(diag)
+		}
+	}
+}
+
+// isOneofVarGuarded reports whether the read is inside `if ok {}` or `if v != nil {}`,
+// or follows `if !ok { return }` or `if v == nil { return }`.
+func isOneofVarGuarded(info *types.Info, n ast.Node, stack []ast.Node, ident *ast.Ident, v oneofVar) bool {
+	isOk := func(expr ast.Expr) bool {
+		okIdent, ok := expr.(*ast.Ident)
+		return ok && v.ok != nil && info.ObjectOf(okIdent) == v.ok
+	}
+
+	for i := len(stack) - 2; i >= 0; i-- {
+		ifStmt, ok := stack[i].(*ast.IfStmt)
+		if !ok || stack[i+1] != ifStmt.Body {
+			continue
+		}
+
+		if hasConjunct(ifStmt.Cond, isOk) || hasNilComparison(info, ifStmt.Cond, ident.Name, token.NEQ) {
+			return true
+		}
+	}
+
+	body := enclosingFuncBody(stack)
+	if body == nil {
+		return false
+	}
+
+	guarded := false
+	ast.Inspect(body, func(node ast.Node) bool {
+		if guarded || node == nil || node.Pos() >= n.Pos() {
+			return false
+		}
+
+		ifStmt, ok := node.(*ast.IfStmt)
+		if !ok || ifStmt.End() >= n.Pos() || !isTerminating(ifStmt.Body) {
+			return true
+		}
+
+		notOk := func(expr ast.Expr) bool {
+			u, ok := expr.(*ast.UnaryExpr)
+			return ok && u.Op == token.NOT && isOk(u.X)
+		}
+
+		if notOk(ifStmt.Cond) || hasNilComparison(info, ifStmt.Cond, ident.Name, token.EQL) {
+			guarded = true
+		}
+
+		return true
+	})
+
+	return guarded
+}
+
+// hasConjunct reports whether the condition or one of its && operands matches.
+func hasConjunct(cond ast.Expr, match func(ast.Expr) bool) bool {
+	switch x := cond.(type) {
+	case *ast.ParenExpr:
+		return hasConjunct(x.X, match)
+
+	case *ast.BinaryExpr:
+		if x.Op == token.LAND {
+			return hasConjunct(x.X, match) || hasConjunct(x.Y, match)
+		}
+	}
+
+	return match(cond)
+}
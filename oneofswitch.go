@@ -0,0 +1,190 @@
+package protogetter
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+const oneofSwitchMsgFormat = "switch on oneof %s is missing cases: %s"
+
+func checkOneofSwitches(pass *analysis.Pass, ins *inspector.Inspector) {
+	nodeTypes := []ast.Node{
+		(*ast.TypeSwitchStmt)(nil),
+		(*ast.SwitchStmt)(nil),
+	}
+
+	ins.Preorder(nodeTypes, func(n ast.Node) {
+		var (
+			oneof   ast.Expr
+			missing []string
+		)
+
+		switch x := n.(type) {
+		case *ast.TypeSwitchStmt:
+			oneof, missing = missingOneofTypeCases(pass, x)
+		case *ast.SwitchStmt:
+			oneof, missing = missingOneofWhichCases(pass, x)
+		}
+
+		if len(missing) == 0 {
+			return
+		}
+
+		pass.Report(analysis.Diagnostic{
+			Pos:      n.Pos(),
+			End:      n.End(),
+			Category: "oneof-exhaustive",
+			Message:  fmt.Sprintf(oneofSwitchMsgFormat, formatNode(oneof), strings.Join(missing, ", ")),
+		})
+	})
+}
+
+// missingOneofTypeCases returns the wrapper types that are not handled by
+// `switch v := m.GetKind().(type)` without a default clause.
+func missingOneofTypeCases(pass *analysis.Pass, stmt *ast.TypeSwitchStmt) (ast.Expr, []string) {
+	var ta *ast.TypeAssertExpr
+	switch x := stmt.Assign.(type) {
+	case *ast.ExprStmt:
+		ta, _ = x.X.(*ast.TypeAssertExpr)
+	case *ast.AssignStmt:
+		if len(x.Rhs) == 1 {
+			ta, _ = x.Rhs[0].(*ast.TypeAssertExpr)
+		}
+	}
+	if ta == nil {
+		return nil, nil
+	}
+
+	if _, _, ok := oneofSource(pass.TypesInfo, ta.X); !ok {
+		return nil, nil
+	}
+
+	named, ok := pass.TypesInfo.TypeOf(ta.X).(*types.Named)
+	if !ok {
+		return nil, nil
+	}
+	iface := named.Underlying().(*types.Interface)
+
+	covered := make(map[string]struct{})
+	for _, stmt := range stmt.Body.List {
+		clause := stmt.(*ast.CaseClause)
+		if clause.List == nil {
+			// The default clause handles all variants.
+			return nil, nil
+		}
+
+		for _, expr := range clause.List {
+			if t := pass.TypesInfo.TypeOf(expr); t != nil {
+				covered[t.String()] = struct{}{}
+			}
+		}
+	}
+
+	file := fileOf(pass, stmt.Pos())
+
+	var missing []string
+	scope := named.Obj().Pkg().Scope()
+	for _, name := range scope.Names() {
+		tn, ok := scope.Lookup(name).(*types.TypeName)
+		if !ok || tn.IsAlias() {
+			continue
+		}
+
+		wrapper := types.NewPointer(tn.Type())
+		if !isOneofWrapper(wrapper, "") || !types.Implements(wrapper, iface) {
+			continue
+		}
+
+		if _, ok := covered[wrapper.String()]; ok {
+			continue
+		}
+
+		missing = append(missing, caseTypeString(pass.Pkg, file, wrapper))
+	}
+
+	sort.Strings(missing)
+
+	return ta.X, missing
+}
+
+// missingOneofWhichCases returns the case constants that are not handled by
+// `switch m.WhichKind()` of the opaque API without a default clause.
+// The not set case is not required, as `case nil` is not required in a type switch.
+func missingOneofWhichCases(pass *analysis.Pass, stmt *ast.SwitchStmt) (ast.Expr, []string) {
+	call, ok := stmt.Tag.(*ast.CallExpr)
+	if !ok || len(call.Args) != 0 {
+		return nil, nil
+	}
+
+	fun, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || !strings.HasPrefix(fun.Sel.Name, "Which") || !isProtoMessage(pass.TypesInfo, fun.X) {
+		return nil, nil
+	}
+
+	caseType, ok := pass.TypesInfo.TypeOf(call).(*types.Named)
+	if !ok || caseType.Obj().Pkg() == nil {
+		return nil, nil
+	}
+
+	covered := make(map[string]struct{})
+	for _, stmt := range stmt.Body.List {
+		clause := stmt.(*ast.CaseClause)
+		if clause.List == nil {
+			return nil, nil
+		}
+
+		for _, expr := range clause.List {
+			if tv, ok := pass.TypesInfo.Types[expr]; ok && tv.Value != nil {
+				covered[tv.Value.ExactString()] = struct{}{}
+			}
+		}
+	}
+
+	file := fileOf(pass, stmt.Pos())
+
+	var missing []string
+	scope := caseType.Obj().Pkg().Scope()
+	for _, name := range scope.Names() {
+		c, ok := scope.Lookup(name).(*types.Const)
+		if !ok || !types.Identical(c.Type(), caseType) || strings.HasSuffix(name, "_not_set_case") {
+			continue
+		}
+
+		if _, ok := covered[c.Val().ExactString()]; ok {
+			continue
+		}
+
+		missing = append(missing, constString(pass.Pkg, file, c))
+	}
+
+	sort.Strings(missing)
+
+	return call, missing
+}
+
+func caseTypeString(pkg *types.Package, file *ast.File, t types.Type) string {
+	if file == nil {
+		return t.String()
+	}
+
+	s, _ := qualifiedTypeString(pkg, file, t)
+	return s
+}
+
+func constString(pkg *types.Package, file *ast.File, c *types.Const) string {
+	if file == nil {
+		return c.Name()
+	}
+
+	name, _ := packageName(pkg, file, c.Pkg())
+	if name == "" {
+		return c.Name()
+	}
+	return name + "." + c.Name()
+}
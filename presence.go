@@ -0,0 +1,237 @@
+package protogetter
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+const (
+	presenceMsgFormat        = "comparing %s with %s can't tell an unset field from a field set to %s"
+	presenceSuggestMsgFormat = presenceMsgFormat + ", use %s to test the presence"
+)
+
+// checkPresenceComparisons reports comparisons of explicit presence getters with the zero value,
+// like `t.GetOptBool() == false` or `t.GetOptEnum() != 0`, which are true both for an unset field
+// and a field set to zero. When the comparison guards an if whose body only reads the same field,
+// it is a presence test and the fix turns it into `t.OptBool != nil` or `t.HasOptBool()`.
+// The field of a message read through a getter, like `t.GetFoo().OptBool`, is only fixed with its Has method.
+func checkPresenceComparisons(pass *analysis.Pass, ins *inspector.Inspector) {
+	ins.WithStack([]ast.Node{(*ast.BinaryExpr)(nil)}, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+
+		expr := n.(*ast.BinaryExpr)
+		if expr.Op != token.EQL && expr.Op != token.NEQ {
+			return true
+		}
+
+		for _, pair := range [][2]ast.Expr{{expr.X, expr.Y}, {expr.Y, expr.X}} {
+			recv, field, ok := presenceGetterCall(pass.TypesInfo, pair[0])
+			if !ok || !isZeroConstant(pass.TypesInfo, pair[1]) {
+				continue
+			}
+
+			reportPresenceComparison(pass, expr, stack, pair[0], pair[1], recv, field)
+			break
+		}
+
+		return true
+	})
+}
+
+// presenceGetterCall checks that the expression is the getter call of an explicit presence field, like `t.GetOptBool()`,
+// and returns the receiver and the field name. The getter returns a value while the field
+// is a pointer or has a `Has` method.
+func presenceGetterCall(info *types.Info, expr ast.Expr) (ast.Expr, string, bool) {
+	call, ok := ast.Unparen(expr).(*ast.CallExpr)
+	if !ok || len(call.Args) != 0 {
+		return nil, "", false
+	}
+
+	fun, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || !strings.HasPrefix(fun.Sel.Name, "Get") || !isProtoMessage(info, fun.X) {
+		return nil, "", false
+	}
+
+	field := strings.TrimPrefix(fun.Sel.Name, "Get")
+	hasPointer, ok := getterResultHasPointer(info, fun.X, field)
+	if !ok || hasPointer {
+		return nil, "", false
+	}
+
+	named, ok := typesNamed(info, fun.X)
+	if !ok {
+		return nil, "", false
+	}
+
+	if _, ok := messageMethod(named, "Has"+field); ok {
+		return fun.X, field, true
+	}
+
+	obj, _, _ := types.LookupFieldOrMethod(named, true, named.Obj().Pkg(), field)
+	if v, ok := obj.(*types.Var); ok && v.IsField() {
+		if _, ok := v.Type().Underlying().(*types.Pointer); ok {
+			return fun.X, field, true
+		}
+	}
+
+	return nil, "", false
+}
+
+// isZeroConstant reports whether the expression is a constant false, zero or empty string.
+func isZeroConstant(info *types.Info, expr ast.Expr) bool {
+	tv, ok := info.Types[expr]
+	if !ok || tv.Value == nil {
+		return false
+	}
+
+	switch tv.Value.Kind() {
+	case constant.Bool:
+		return !constant.BoolVal(tv.Value)
+	case constant.String:
+		return constant.StringVal(tv.Value) == ""
+	case constant.Int, constant.Float:
+		return constant.Sign(tv.Value) == 0
+	}
+
+	return false
+}
+
+func reportPresenceComparison(pass *analysis.Pass, expr *ast.BinaryExpr, stack []ast.Node, getter, zero, recv ast.Expr, field string) {
+	diag := analysis.Diagnostic{
+		Pos:      expr.Pos(),
+		End:      expr.End(),
+		Category: "presence",
+		Message:  fmt.Sprintf(presenceMsgFormat, formatNode(getter), formatNode(zero), formatNode(zero)),
+	}
+
+	if !guardsFieldUse(pass.TypesInfo, stack, recv, field) {
+		pass.Report(diag)
+		return
+	}
+
+	var replacement string
+	named, _ := typesNamed(pass.TypesInfo, recv)
+	if _, ok := messageMethod(named, "Has"+field); ok {
+		replacement = fmt.Sprintf("%s.Has%s()", formatNode(recv), field)
+		if expr.Op == token.EQL {
+			replacement = "!" + replacement
+		}
+	} else if _, ok := ast.Unparen(recv).(*ast.Ident); ok {
+		replacement = fmt.Sprintf("%s.%s %s nil", formatNode(recv), field, expr.Op)
+	} else {
+		// The Has methods check their receiver for nil, reading the field of `t.GetFoo()` directly doesn't.
+		pass.Report(diag)
+		return
+	}
+
+	diag.Message = fmt.Sprintf(presenceSuggestMsgFormat, formatNode(getter), formatNode(zero), formatNode(zero), replacement)
+	diag.SuggestedFixes = []analysis.SuggestedFix{
+		{
+			Message: "use " + replacement,
+			TextEdits: []analysis.TextEdit{
+				{
+					Pos:     expr.Pos(),
+					End:     expr.End(),
+					NewText: []byte(replacement),
+				},
+			},
+		},
+	}
+
+	pass.Report(diag)
+}
+
+// guardsFieldUse reports whether the comparison on top of the stack is the condition of an if,
+// possibly joined with && or ||, whose body only reads the same field of the same message.
+// A body writing the field may rely on a field set to zero being handled like an unset one.
+func guardsFieldUse(info *types.Info, stack []ast.Node, recv ast.Expr, field string) bool {
+	for i := len(stack) - 2; i >= 0; i-- {
+		switch x := stack[i].(type) {
+		case *ast.ParenExpr:
+			continue
+
+		case *ast.BinaryExpr:
+			if x.Op == token.LAND || x.Op == token.LOR {
+				continue
+			}
+
+		case *ast.IfStmt:
+			if x.Cond == stack[i+1] {
+				return readsFieldOnly(info, x.Body, accessPath(info, recv), field)
+			}
+		}
+
+		return false
+	}
+
+	return false
+}
+
+// readsFieldOnly reports whether the node reads the field of the message with the path,
+// directly or with its getter or Has method, and never writes it or takes its address.
+func readsFieldOnly(info *types.Info, node ast.Node, path, field string) bool {
+	written := make(map[ast.Expr]struct{})
+	ast.Inspect(node, func(n ast.Node) bool {
+		switch x := n.(type) {
+		case *ast.AssignStmt:
+			for _, lhs := range x.Lhs {
+				written[fieldOperand(lhs)] = struct{}{}
+			}
+		case *ast.IncDecStmt:
+			written[fieldOperand(x.X)] = struct{}{}
+		case *ast.UnaryExpr:
+			if x.Op == token.AND {
+				written[fieldOperand(x.X)] = struct{}{}
+			}
+		}
+		return true
+	})
+
+	read, write := false, false
+	ast.Inspect(node, func(n ast.Node) bool {
+		sel, ok := n.(*ast.SelectorExpr)
+		if !ok || write {
+			return !write
+		}
+
+		if !isProtoMessage(info, sel.X) || accessPath(info, sel.X) != path {
+			return true
+		}
+
+		switch sel.Sel.Name {
+		case field:
+			if _, ok := written[sel]; ok {
+				write = true
+			} else {
+				read = true
+			}
+		case "Get" + field, "Has" + field:
+			read = true
+		case "Set" + field, "Clear" + field:
+			write = true
+		}
+
+		return !write
+	})
+
+	return read && !write
+}
+
+// fieldOperand returns the expression written through, like `t.OptBool` for `*t.OptBool`.
+func fieldOperand(expr ast.Expr) ast.Expr {
+	expr = ast.Unparen(expr)
+	if star, ok := expr.(*ast.StarExpr); ok {
+		return ast.Unparen(star.X)
+	}
+
+	return expr
+}
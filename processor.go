@@ -126,6 +126,14 @@ func (c *processor) process(n ast.Node) (*Result, error) {
 				return &Result{}, nil
 			}
 
+			if c.cfg.CheckGetterWrites && isMutatingMethod(fun.Sel.Name) {
+				// Don't suggest a getter for the receiver of a setter,
+				// the getter returns nil for an unset field and the call will panic.
+				if recv, ok := fun.X.(*ast.SelectorExpr); ok {
+					c.filter.AddPos(recv.Sel.Pos())
+				}
+			}
+
 			c.processInner(x)
 
 		default:
@@ -133,7 +141,8 @@ func (c *processor) process(n ast.Node) (*Result, error) {
 		}
 
 	case *ast.SelectorExpr:
-		if !isProtoMessage(c.info, x.X) {
+		_, isOneofAssert := oneofAssertParent(c.info, x)
+		if !isProtoMessage(c.info, x.X) && !isOneofAssert {
 			// If the selector is not on a proto message, skip it.
 			return &Result{}, nil
 		}
@@ -233,6 +242,15 @@ func (c *processor) processInner(expr ast.Expr) {
 		c.processInner(x.X)
 
 	case *ast.SelectorExpr:
+		// Reading the field of a oneof wrapper panics when another variant is set,
+		// so use the getter of the message containing the oneof.
+		if parent, ok := oneofAssertParent(c.info, x); ok {
+			c.processInner(parent)
+			c.writeFrom(strings.TrimPrefix(formatNode(x), formatNode(parent)))
+			c.writeTo(".Get" + x.Sel.Name + "()")
+			return
+		}
+
 		c.processInner(x.X)
 		c.write(".")
 
@@ -315,11 +333,24 @@ func (r *Result) Skipped() bool {
 }
 
 func isProtoMessage(info *types.Info, expr ast.Expr) bool {
+	if info == nil {
+		return false
+	}
+
+	return isProtoMessageType(info.TypeOf(expr))
+}
+
+func isProtoMessageType(t types.Type) bool {
+	named, ok := namedType(t)
+	if !ok {
+		return false
+	}
+
 	// First, we are checking for the presence of the ProtoReflect method which is currently being generated
 	// and corresponds to v2 version.
 	// https://pkg.go.dev/google.golang.org/protobuf@v1.31.0/proto#Message
 	const protoV2Method = "ProtoReflect"
-	ok := methodIsExists(info, expr, protoV2Method)
+	ok = namedHasMethod(named, protoV2Method)
 	if ok {
 		return true
 	}
@@ -329,23 +360,39 @@ func isProtoMessage(info *types.Info, expr ast.Expr) bool {
 	// continues to exist for compatibility.
 	// https://pkg.go.dev/github.com/golang/protobuf/proto?utm_source=godoc#Message
 	const protoV1Method = "ProtoMessage"
-	ok = methodIsExists(info, expr, protoV1Method)
+	ok = namedHasMethod(named, protoV1Method)
 	if ok {
 		// Since there is a protoc-gen-gogo generator that implements the proto.Message interface, but may not generate
 		// getters or generate from without checking for nil, so even if getters exist, we skip them.
 		const protocGenGoGoMethod = "MarshalToSizedBuffer"
-		return !methodIsExists(info, expr, protocGenGoGoMethod)
+		return !namedHasMethod(named, protocGenGoGoMethod)
 	}
 
 	return false
 }
 
+// isProtoMessagePointer reports whether t is a pointer to a proto message struct.
+func isProtoMessagePointer(t types.Type) bool {
+	if t == nil {
+		return false
+	}
+
+	if _, ok := t.Underlying().(*types.Pointer); !ok {
+		return false
+	}
+
+	return isProtoMessageType(t)
+}
+
 func typesNamed(info *types.Info, x ast.Expr) (*types.Named, bool) {
 	if info == nil {
 		return nil, false
 	}
 
-	t := info.TypeOf(x)
+	return namedType(info.TypeOf(x))
+}
+
+func namedType(t types.Type) (*types.Named, bool) {
 	if t == nil {
 		return nil, false
 	}
@@ -363,12 +410,32 @@ func typesNamed(info *types.Info, x ast.Expr) (*types.Named, bool) {
 	return named, true
 }
 
+// wellKnownType returns the name of the well-known type, like `timestamppb.Timestamp`,
+// or an empty string if the type is not one of the types with helpers.
+func wellKnownType(t types.Type) string {
+	named, ok := namedType(t)
+	if !ok || named.Obj().Pkg() == nil {
+		return ""
+	}
+
+	switch pkg := named.Obj().Pkg(); pkg.Path() {
+	case timestamppbPkgPath, durationpbPkgPath, wrapperspbPkgPath, structpbPkgPath, anypbPkgPath:
+		return pkg.Name() + "." + named.Obj().Name()
+	}
+
+	return ""
+}
+
 func methodIsExists(info *types.Info, x ast.Expr, name string) bool {
 	named, ok := typesNamed(info, x)
 	if !ok {
 		return false
 	}
 
+	return namedHasMethod(named, name)
+}
+
+func namedHasMethod(named *types.Named, name string) bool {
 	for i := 0; i < named.NumMethods(); i++ {
 		if named.Method(i).Name() == name {
 			return true
@@ -7,6 +7,7 @@ import (
 	"go/ast"
 	"go/format"
 	"go/token"
+	"go/types"
 	"log"
 	"path/filepath"
 	"strings"
@@ -50,6 +51,30 @@ func flags(opts *Config) flag.FlagSet {
 		return nil
 	})
 	fs.BoolVar(&opts.SkipAnyGenerated, "skip-any-generated", false, "skip any generated files")
+	fs.BoolVar(&opts.CheckNestedWrites, "check-nested-writes", opts.CheckNestedWrites, "report writes through possibly nil parent messages")
+	fs.BoolVar(&opts.CheckGetterWrites, "check-getter-writes", opts.CheckGetterWrites, "report writes to messages returned by getters")
+	fs.BoolVar(&opts.CheckOneofExhaustive, "check-oneof-exhaustive", opts.CheckOneofExhaustive, "report switches over oneof fields with missing cases")
+	fs.BoolVar(&opts.CheckEnums, "check-enums", opts.CheckEnums, "report non-exhaustive switches, unchecked conversions and literal comparisons of proto enums")
+	fs.BoolVar(&opts.CheckCopies, "check-copies", opts.CheckCopies, "report copies of proto message structs by value")
+	fs.BoolVar(&opts.CheckComparisons, "check-comparisons", opts.CheckComparisons, "report comparisons of proto messages without proto.Equal")
+	fs.BoolVar(&opts.CheckSerializers, "check-serializers", opts.CheckSerializers, "report proto messages passed to json, yaml, gob and xml encoders")
+	fs.BoolVar(&opts.CheckLegacyAPI, "check-legacy-api", opts.CheckLegacyAPI, "report deprecated github.com/golang/protobuf APIs")
+	fs.BoolVar(&opts.CheckDeprecated, "check-deprecated", opts.CheckDeprecated, "report uses of deprecated proto fields and enum values")
+	fs.BoolVar(&opts.CheckNilMapWrites, "check-nil-map-writes", opts.CheckNilMapWrites, "report writes to proto map fields that may be nil")
+	fs.BoolVar(&opts.CheckRepeatedIndex, "check-repeated-index", opts.CheckRepeatedIndex, "report indexing of repeated field getters without a len check")
+	fs.BoolVar(&opts.CheckSliceAliasing, "check-slice-aliasing", opts.CheckSliceAliasing, "report repeated fields shared or mutated through aliases")
+	fs.BoolVar(&opts.CheckWellKnownTypes, "check-well-known-types", opts.CheckWellKnownTypes, "report manual conversions of well-known types which have helpers")
+	fs.BoolVar(&opts.CheckAnyTypes, "check-any-types", opts.CheckAnyTypes, "report type URL string comparisons and unmarshal targets not matching MessageIs guards")
+	fs.BoolVar(&opts.CheckFieldMasks, "check-field-masks", opts.CheckFieldMasks, "report constant field mask paths not matching the message fields")
+	fs.BoolVar(&opts.CheckReflectLookups, "check-reflect-lookups", opts.CheckReflectLookups, "report protoreflect lookups with constant names and numbers that never succeed")
+	fs.BoolVar(&opts.CheckReflectValues, "check-reflect-values", opts.CheckReflectValues, "report protoreflect values not matching the kind of the statically known field")
+	fs.BoolVar(&opts.CheckGoReflection, "check-go-reflection", opts.CheckGoReflection, "report Go reflection and reflection based copiers applied to proto messages")
+	fs.BoolVar(&opts.CheckXXXMembers, "check-xxx-members", opts.CheckXXXMembers, "report XXX_ fields and methods of proto messages generated by old protoc-gen-go versions")
+	fs.BoolVar(&opts.CheckHybridAPI, "check-hybrid-api", opts.CheckHybridAPI, "report direct field access of hybrid API messages that have Has, Clear or Set accessors")
+	fs.BoolVar(&opts.CheckZeroComparisons, "check-zero-comparisons", opts.CheckZeroComparisons, "report zero value comparisons of explicit presence getters")
+	fs.BoolVar(&opts.CheckGogo, "check-gogo", opts.CheckGogo, "report reads through pointer fields of gogo/protobuf messages where nil-safe getters prevent a panic")
+	fs.BoolVar(&opts.ReportGogoMigration, "report-gogo-migration", opts.ReportGogoMigration, "report the uses of gogo-only field options and methods that break when moving to golang/protobuf, grouped by field")
+	fs.BoolVar(&opts.MigrateOpaque, "migrate-opaque", opts.MigrateOpaque, "fix hybrid API messages for the opaque API and report what can't be fixed")
 
 	return *fs
 }
@@ -59,6 +84,30 @@ type Config struct {
 	SkipFiles               []string
 	SkipAnyGenerated        bool
 	ReplaceFirstArgInAppend bool
+	CheckNestedWrites       bool
+	CheckGetterWrites       bool
+	CheckOneofExhaustive    bool
+	CheckEnums              bool
+	CheckCopies             bool
+	CheckComparisons        bool
+	CheckSerializers        bool
+	CheckLegacyAPI          bool
+	CheckDeprecated         bool
+	CheckNilMapWrites       bool
+	CheckRepeatedIndex      bool
+	CheckSliceAliasing      bool
+	CheckWellKnownTypes     bool
+	CheckAnyTypes           bool
+	CheckFieldMasks         bool
+	CheckReflectLookups     bool
+	CheckReflectValues      bool
+	CheckGoReflection       bool
+	CheckXXXMembers         bool
+	CheckHybridAPI          bool
+	MigrateOpaque           bool
+	CheckZeroComparisons    bool
+	CheckGogo               bool
+	ReportGogoMigration     bool
 }
 
 func Run(pass *analysis.Pass, cfg *Config) error {
@@ -118,14 +167,128 @@ func Run(pass *analysis.Pass, cfg *Config) error {
 	ins := inspector.New(files)
 
 	filter := NewPosFilter()
+
+	// The helper fixes replace the field reads, so they are filtered before the getter check.
+	if cfg.CheckWellKnownTypes {
+		checkWellKnownTypes(pass, ins, filter)
+	}
+
+	// The migration reports the field accesses no other diagnostic covers, so it tracks all of them.
+	var reported reportedRanges
+	if cfg.MigrateOpaque {
+		defer trackReports(pass, &reported)()
+	}
+
+	if cfg.CheckHybridAPI || cfg.MigrateOpaque {
+		checkHybridAPI(pass, ins, filter)
+	}
+
+	if cfg.MigrateOpaque {
+		migrateOpaqueLiterals(pass, ins)
+	}
+
 	ins.Preorder(nodeTypes, func(node ast.Node) {
 		report := analyse(pass, filter, node, cfg)
 		if report == nil {
 			return
 		}
+
+		if cfg.CheckRepeatedIndex {
+			report.unsafeIndex = hasUnguardedRepeatedIndex(pass, node)
+		}
 		pass.Report(report.ToDiagReport())
 	})
 
+	checkOneofWrapperReads(pass, ins, filter)
+
+	if cfg.CheckNestedWrites {
+		checkNestedWrites(pass, ins)
+	}
+
+	if cfg.CheckGetterWrites {
+		checkGetterWrites(pass, ins)
+	}
+
+	if cfg.CheckOneofExhaustive {
+		checkOneofSwitches(pass, ins)
+	}
+
+	if cfg.CheckEnums {
+		checkEnums(pass, ins)
+	}
+
+	if cfg.CheckCopies {
+		checkCopies(pass, ins)
+	}
+
+	if cfg.CheckComparisons {
+		checkComparisons(pass, ins)
+	}
+
+	if cfg.CheckSerializers {
+		checkSerializers(pass, ins)
+	}
+
+	if cfg.CheckLegacyAPI {
+		checkLegacyAPI(pass, ins)
+	}
+
+	if cfg.CheckDeprecated {
+		checkDeprecated(pass, ins)
+	}
+
+	if cfg.CheckNilMapWrites {
+		checkNilMapWrites(pass, ins)
+	}
+
+	if cfg.CheckRepeatedIndex {
+		checkRepeatedIndexes(pass, ins)
+	}
+
+	if cfg.CheckSliceAliasing {
+		checkSliceAliasing(pass, ins)
+	}
+
+	if cfg.CheckAnyTypes {
+		checkAnyTypes(pass, ins)
+	}
+
+	if cfg.CheckFieldMasks {
+		checkFieldMasks(pass, ins)
+	}
+
+	if cfg.CheckReflectLookups {
+		checkReflectLookups(pass, ins)
+	}
+
+	if cfg.CheckReflectValues {
+		checkReflectValues(pass, ins)
+	}
+
+	if cfg.CheckGoReflection {
+		checkGoReflection(pass, ins)
+	}
+
+	if cfg.CheckXXXMembers {
+		checkXXXMembers(pass, ins)
+	}
+
+	if cfg.CheckZeroComparisons {
+		checkPresenceComparisons(pass, ins)
+	}
+
+	if cfg.CheckGogo {
+		checkGogoGetters(pass, ins)
+	}
+
+	if cfg.ReportGogoMigration {
+		reportGogoMigration(pass, ins)
+	}
+
+	if cfg.MigrateOpaque {
+		reportUnmigratedFields(pass, ins, reported)
+	}
+
 	return nil
 }
 
@@ -173,10 +336,15 @@ func analyse(pass *analysis.Pass, filter *PosFilter, n ast.Node, cfg *Config) *R
 type Report struct {
 	node   ast.Node
 	result *Result
+	// unsafeIndex marks the fixes that index a repeated field without a len check.
+	unsafeIndex bool
 }
 
 func (r *Report) ToDiagReport() analysis.Diagnostic {
 	msg := fmt.Sprintf(msgFormat, r.result.From, r.result.To)
+	if r.unsafeIndex {
+		msg += stillUnsafeIndexSuffix
+	}
 
 	return analysis.Diagnostic{
 		Pos:     r.node.Pos(),
@@ -235,3 +403,61 @@ func formatNode(node ast.Node) string {
 
 	return buf.String()
 }
+
+func fileOf(pass *analysis.Pass, pos token.Pos) *ast.File {
+	for _, f := range pass.Files {
+		if f.FileStart <= pos && pos < f.FileEnd {
+			return f
+		}
+	}
+
+	return nil
+}
+
+// indentOf returns the indentation of the line at the given position, assuming the file is gofmt-ed.
+func indentOf(fset *token.FileSet, pos token.Pos) string {
+	return strings.Repeat("\t", fset.Position(pos).Column-1)
+}
+
+// qualifiedTypeString formats the type as it should be written in the file,
+// returning false if the file does not import a package the type refers to.
+func qualifiedTypeString(pkg *types.Package, file *ast.File, t types.Type) (string, bool) {
+	ok := true
+	s := types.TypeString(t, func(other *types.Package) string {
+		name, found := packageName(pkg, file, other)
+		if !found {
+			ok = false
+		}
+		return name
+	})
+
+	return s, ok
+}
+
+// packageName returns the name used in the file to refer to the other package,
+// which is empty for the current package or a dot import.
+func packageName(pkg *types.Package, file *ast.File, other *types.Package) (string, bool) {
+	if other == pkg {
+		return "", true
+	}
+
+	for _, imp := range file.Imports {
+		if strings.Trim(imp.Path.Value, `"`) != other.Path() {
+			continue
+		}
+
+		if imp.Name == nil {
+			return other.Name(), true
+		}
+
+		switch imp.Name.Name {
+		case ".":
+			return "", true
+		case "_":
+			continue
+		}
+		return imp.Name.Name, true
+	}
+
+	return other.Name(), false
+}
@@ -16,3 +16,219 @@ func Test(t *testing.T) {
 
 	analysistest.Run(t, testdata, protogetter.NewAnalyzer(cfg), "./proto/...")
 }
+
+func TestNestedWrites(t *testing.T) {
+	cfg := &protogetter.Config{
+		CheckNestedWrites: true,
+	}
+
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, protogetter.NewAnalyzer(cfg), "./nestedwrite")
+}
+
+func TestGetterWrites(t *testing.T) {
+	cfg := &protogetter.Config{
+		CheckGetterWrites: true,
+	}
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, protogetter.NewAnalyzer(cfg), "./getterwrite")
+}
+
+func TestOneofExhaustive(t *testing.T) {
+	cfg := &protogetter.Config{
+		CheckOneofExhaustive: true,
+	}
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, protogetter.NewAnalyzer(cfg), "./oneofswitch")
+}
+
+func TestEnums(t *testing.T) {
+	cfg := &protogetter.Config{
+		CheckEnums: true,
+	}
+
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, protogetter.NewAnalyzer(cfg), "./enum")
+}
+
+func TestCopies(t *testing.T) {
+	cfg := &protogetter.Config{
+		CheckCopies: true,
+	}
+
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, protogetter.NewAnalyzer(cfg), "./copycheck")
+}
+
+func TestComparisons(t *testing.T) {
+	cfg := &protogetter.Config{
+		CheckComparisons: true,
+	}
+
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, protogetter.NewAnalyzer(cfg), "./compare")
+}
+
+func TestSerializers(t *testing.T) {
+	cfg := &protogetter.Config{
+		CheckSerializers: true,
+	}
+
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, protogetter.NewAnalyzer(cfg), "./serializer")
+}
+
+func TestLegacyAPI(t *testing.T) {
+	cfg := &protogetter.Config{
+		CheckLegacyAPI: true,
+	}
+
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, protogetter.NewAnalyzer(cfg), "./legacy")
+}
+
+func TestDeprecated(t *testing.T) {
+	cfg := &protogetter.Config{
+		CheckDeprecated: true,
+	}
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, protogetter.NewAnalyzer(cfg), "./deprecated")
+}
+
+func TestNilMapWrites(t *testing.T) {
+	cfg := &protogetter.Config{
+		CheckNilMapWrites: true,
+	}
+
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, protogetter.NewAnalyzer(cfg), "./mapwrite")
+}
+
+func TestRepeatedIndex(t *testing.T) {
+	cfg := &protogetter.Config{
+		CheckRepeatedIndex: true,
+	}
+
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, protogetter.NewAnalyzer(cfg), "./repeatedindex")
+}
+
+func TestSliceAliasing(t *testing.T) {
+	cfg := &protogetter.Config{
+		CheckSliceAliasing: true,
+	}
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, protogetter.NewAnalyzer(cfg), "./slicealias")
+}
+
+func TestWellKnownTypes(t *testing.T) {
+	cfg := &protogetter.Config{
+		CheckWellKnownTypes: true,
+	}
+
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, protogetter.NewAnalyzer(cfg), "./wkt")
+}
+
+func TestAnyTypes(t *testing.T) {
+	cfg := &protogetter.Config{
+		CheckAnyTypes: true,
+	}
+
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, protogetter.NewAnalyzer(cfg), "./anytype")
+}
+
+func TestFieldMasks(t *testing.T) {
+	cfg := &protogetter.Config{
+		CheckFieldMasks: true,
+	}
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, protogetter.NewAnalyzer(cfg), "./fieldmask")
+}
+
+func TestReflectLookups(t *testing.T) {
+	cfg := &protogetter.Config{
+		CheckReflectLookups: true,
+	}
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, protogetter.NewAnalyzer(cfg), "./reflectlookup")
+}
+
+func TestReflectValues(t *testing.T) {
+	cfg := &protogetter.Config{
+		CheckReflectValues: true,
+	}
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, protogetter.NewAnalyzer(cfg), "./reflectvalue")
+}
+
+func TestGoReflection(t *testing.T) {
+	cfg := &protogetter.Config{
+		CheckGoReflection: true,
+	}
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, protogetter.NewAnalyzer(cfg), "./goreflect")
+}
+
+func TestXXXMembers(t *testing.T) {
+	cfg := &protogetter.Config{
+		CheckXXXMembers: true,
+	}
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, protogetter.NewAnalyzer(cfg), "./xxxmembers")
+}
+
+func TestHybridAPI(t *testing.T) {
+	cfg := &protogetter.Config{
+		CheckHybridAPI: true,
+	}
+
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, protogetter.NewAnalyzer(cfg), "./hybrid")
+}
+
+func TestMigrateOpaque(t *testing.T) {
+	cfg := &protogetter.Config{
+		MigrateOpaque: true,
+	}
+
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, protogetter.NewAnalyzer(cfg), "./migrateopaque")
+}
+
+func TestZeroComparisons(t *testing.T) {
+	cfg := &protogetter.Config{
+		CheckZeroComparisons: true,
+	}
+
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, protogetter.NewAnalyzer(cfg), "./presence")
+}
+
+func TestGogo(t *testing.T) {
+	cfg := &protogetter.Config{
+		CheckGogo: true,
+	}
+
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, protogetter.NewAnalyzer(cfg), "./gogo")
+}
+
+func TestGogoMigration(t *testing.T) {
+	cfg := &protogetter.Config{
+		ReportGogoMigration: true,
+	}
+
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, protogetter.NewAnalyzer(cfg), "./gogomigrate")
+}
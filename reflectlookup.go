@@ -0,0 +1,378 @@
+package protogetter
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/types"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+const (
+	reflectLookupMsgFormat   = "%s never succeeds, message %s has no %s"
+	reflectRegistryMsgFormat = "%s may not find %q, no message of proto package %q imported here has this name"
+)
+
+const (
+	protoreflectPkgPath  = "google.golang.org/protobuf/reflect/protoreflect"
+	protoregistryPkgPath = "google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// checkReflectLookups reports protoreflect lookups with constant arguments that always return nil:
+// `m.ProtoReflect().Descriptor().Fields().ByName("bogus")`, `Fields().ByNumber(99)`, `Oneofs().ByName("x")`
+// and `protoregistry.GlobalTypes.FindMessageByName("foo.v1.Tset")` of a known proto package.
+// The message is resolved from the `ProtoReflect()` call, directly or through variables,
+// and checked against the descriptor embedded into the generated file.
+func checkReflectLookups(pass *analysis.Pass, ins *inspector.Inspector) {
+	files := make(generatedFiles)
+	resolver := newMessageResolver(pass, files)
+
+	// vars are the variables holding a protoreflect.Message, MessageType or MessageDescriptor of a known message.
+	vars := make(map[types.Object]types.Type)
+
+	nodeTypes := []ast.Node{
+		(*ast.AssignStmt)(nil),
+		(*ast.ValueSpec)(nil),
+		(*ast.CallExpr)(nil),
+	}
+
+	ins.Preorder(nodeTypes, func(n ast.Node) {
+		switch x := n.(type) {
+		case *ast.AssignStmt:
+			for i, lhs := range x.Lhs {
+				if ident, ok := lhs.(*ast.Ident); ok {
+					addReflectVar(pass.TypesInfo, vars, ident, assignedValue(x, i))
+				}
+			}
+
+		case *ast.ValueSpec:
+			if len(x.Names) != len(x.Values) {
+				return
+			}
+
+			for i, name := range x.Names {
+				addReflectVar(pass.TypesInfo, vars, name, x.Values[i])
+			}
+
+		case *ast.CallExpr:
+			fn, ok := typeutil.Callee(pass.TypesInfo, x).(*types.Func)
+			if !ok || fn.Pkg() == nil || len(x.Args) != 1 {
+				return
+			}
+
+			switch fn.Pkg().Path() {
+			case protoreflectPkgPath:
+				checkDescriptorLookup(pass, files, vars, x)
+			case protoregistryPkgPath:
+				checkRegistryLookup(pass, resolver, x, fn)
+			}
+		}
+	})
+}
+
+// addReflectVar tracks the variable assigned the value, a nil value is not known.
+// The variable is forgotten when it is assigned a value of an unknown message,
+// so the lookups after `m = other()` are not checked against the previous message.
+func addReflectVar(info *types.Info, vars map[types.Object]types.Type, ident *ast.Ident, value ast.Expr) {
+	obj := info.ObjectOf(ident)
+	if obj == nil {
+		return
+	}
+
+	if t := reflectMessageType(info, vars, value); t != nil {
+		vars[obj] = t
+	} else {
+		delete(vars, obj)
+	}
+}
+
+// assignedValue returns the value assigned to the i-th left hand side of the statement,
+// or nil if the right hand side is a single call or assertion returning several values.
+func assignedValue(assign *ast.AssignStmt, i int) ast.Expr {
+	if len(assign.Lhs) != len(assign.Rhs) {
+		return nil
+	}
+	return assign.Rhs[i]
+}
+
+// reflectMessageType returns the message type of the protoreflect.Message, MessageType or MessageDescriptor expression,
+// like `m.ProtoReflect().Descriptor()`, or nil if the message is not known.
+func reflectMessageType(info *types.Info, vars map[types.Object]types.Type, expr ast.Expr) types.Type {
+	switch x := ast.Unparen(expr).(type) {
+	case *ast.Ident:
+		return vars[info.ObjectOf(x)]
+
+	case *ast.CallExpr:
+		sel, ok := x.Fun.(*ast.SelectorExpr)
+		if !ok || len(x.Args) != 0 {
+			return nil
+		}
+
+		switch sel.Sel.Name {
+		case "ProtoReflect":
+			if t := info.TypeOf(sel.X); isProtoMessagePointer(t) {
+				return t
+			}
+
+		case "Descriptor", "Type":
+			named, ok := namedType(info.TypeOf(x))
+			if ok && named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == protoreflectPkgPath {
+				return reflectMessageType(info, vars, sel.X)
+			}
+		}
+	}
+
+	return nil
+}
+
+// descriptorLookup is a lookup in the fields or the oneofs of a known message, like `md.Fields().ByName("foo")`.
+type descriptorLookup struct {
+	// list is the method returning the descriptors, `Fields` or `Oneofs`.
+	list string
+	// method is the lookup method, like `ByName`.
+	method string
+	// msg is the Go type of the message.
+	msg *types.Named
+	// desc and msgName are the descriptor and the full name of the message.
+	desc    *descriptorpb.DescriptorProto
+	msgName string
+	// arg is the constant argument of the lookup.
+	arg constant.Value
+}
+
+// resolveDescriptorLookup checks that the call is a lookup with a constant argument
+// in the fields or the oneofs of a known message.
+func resolveDescriptorLookup(pass *analysis.Pass, files generatedFiles, vars map[types.Object]types.Type, call *ast.CallExpr) (descriptorLookup, bool) {
+	fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Pkg().Path() != protoreflectPkgPath || len(call.Args) != 1 {
+		return descriptorLookup{}, false
+	}
+
+	recv := fn.Type().(*types.Signature).Recv()
+	if recv == nil {
+		return descriptorLookup{}, false
+	}
+
+	list, ok := namedType(recv.Type())
+	if !ok || (list.Obj().Name() != "FieldDescriptors" && list.Obj().Name() != "OneofDescriptors") {
+		return descriptorLookup{}, false
+	}
+
+	tv := pass.TypesInfo.Types[call.Args[0]]
+	if tv.Value == nil {
+		return descriptorLookup{}, false
+	}
+
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return descriptorLookup{}, false
+	}
+
+	listCall, ok := ast.Unparen(sel.X).(*ast.CallExpr)
+	if !ok || len(listCall.Args) != 0 {
+		return descriptorLookup{}, false
+	}
+
+	listSel, ok := listCall.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return descriptorLookup{}, false
+	}
+
+	named, ok := namedType(reflectMessageType(pass.TypesInfo, vars, listSel.X))
+	if !ok {
+		return descriptorLookup{}, false
+	}
+
+	f := files.of(pass.Fset, named.Obj())
+	if f == nil {
+		return descriptorLookup{}, false
+	}
+
+	desc := f.message(named.Obj().Name())
+	msgName, _ := f.messageFullName(named.Obj().Name())
+	if desc == nil {
+		return descriptorLookup{}, false
+	}
+
+	return descriptorLookup{
+		list:    listSel.Sel.Name,
+		method:  fn.Name(),
+		msg:     named,
+		desc:    desc,
+		msgName: msgName,
+		arg:     tv.Value,
+	}, true
+}
+
+// field returns the field found by the lookup, nil if there is none or the lookup is not in the fields.
+func (l descriptorLookup) field() *descriptorpb.FieldDescriptorProto {
+	if l.list != "Fields" {
+		return nil
+	}
+
+	for _, fd := range l.desc.GetField() {
+		switch l.method {
+		case "ByName", "ByTextName":
+			if l.arg.Kind() == constant.String && fd.GetName() == constant.StringVal(l.arg) {
+				return fd
+			}
+
+		case "ByJSONName":
+			if l.arg.Kind() == constant.String && fd.GetJsonName() == constant.StringVal(l.arg) {
+				return fd
+			}
+
+		case "ByNumber":
+			if number, ok := constant.Int64Val(constant.ToInt(l.arg)); ok && int64(fd.GetNumber()) == number {
+				return fd
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkDescriptorLookup reports `Fields().ByName(name)`, `Fields().ByNumber(n)` and `Oneofs().ByName(name)`
+// of a known message that never find anything.
+func checkDescriptorLookup(pass *analysis.Pass, files generatedFiles, vars map[types.Object]types.Type, call *ast.CallExpr) {
+	lookup, ok := resolveDescriptorLookup(pass, files, vars, call)
+	if !ok {
+		return
+	}
+
+	var (
+		names   []string
+		missing string
+	)
+
+	switch lookup.list + "." + lookup.method {
+	case "Fields.ByName", "Fields.ByTextName":
+		names = fieldNames(lookup.desc.GetField())
+		missing = "field"
+
+	case "Fields.ByJSONName":
+		for _, fd := range lookup.desc.GetField() {
+			if fd.JsonName == nil {
+				// The descriptor was generated without JSON names, nothing to compare with.
+				return
+			}
+			names = append(names, fd.GetJsonName())
+		}
+		missing = "field with JSON name"
+
+	case "Fields.ByNumber":
+		number, ok := constant.Int64Val(constant.ToInt(lookup.arg))
+		if !ok || lookup.field() != nil {
+			return
+		}
+
+		what := fmt.Sprintf("%s().%s", lookup.list, lookup.method)
+		pass.Report(analysis.Diagnostic{
+			Pos:      call.Pos(),
+			End:      call.End(),
+			Category: "reflect-lookup",
+			Message:  fmt.Sprintf(reflectLookupMsgFormat, what, lookup.msgName, "field number "+strconv.FormatInt(number, 10)),
+		})
+		return
+
+	case "Oneofs.ByName":
+		for _, oneof := range lookup.desc.GetOneofDecl() {
+			names = append(names, oneof.GetName())
+		}
+		missing = "oneof"
+
+	default:
+		return
+	}
+
+	if lookup.arg.Kind() != constant.String {
+		return
+	}
+
+	name := constant.StringVal(lookup.arg)
+	for _, candidate := range names {
+		if candidate == name {
+			return
+		}
+	}
+
+	what := fmt.Sprintf("%s().%s", lookup.list, lookup.method)
+	msg := fmt.Sprintf(reflectLookupMsgFormat, what, lookup.msgName, fmt.Sprintf("%s %q", missing, name))
+	if suggestion, ok := closestName(names, name); ok {
+		msg += fmt.Sprintf(didYouMeanSuffix, suggestion)
+	}
+
+	pass.Report(analysis.Diagnostic{
+		Pos:      call.Pos(),
+		End:      call.End(),
+		Category: "reflect-lookup",
+		Message:  msg,
+	})
+}
+
+// checkRegistryLookup hints at `FindMessageByName` and `FindMessageByURL` of the registry with a message
+// missing from a proto package known to the analyzed package, when the name is close to a known message.
+// The registry holds every message linked into the program and a proto package may span several Go packages,
+// while only the package and its imports are visible here, so a missing name alone is not a mistake,
+// only a likely typo is reported.
+func checkRegistryLookup(pass *analysis.Pass, resolver *messageResolver, call *ast.CallExpr, fn *types.Func) {
+	if fn.Name() != "FindMessageByName" && fn.Name() != "FindMessageByURL" {
+		return
+	}
+
+	name, ok := constantString(pass.TypesInfo, call.Args[0])
+	if !ok {
+		return
+	}
+	name = name[strings.LastIndex(name, "/")+1:]
+
+	if _, ok := resolver.lookup(name); ok {
+		return
+	}
+
+	pkg, ok := protoPackageOf(resolver, name)
+	if !ok {
+		return
+	}
+
+	var candidates []string
+	for _, candidate := range resolver.names() {
+		if p, ok := protoPackageOf(resolver, candidate); ok && p == pkg {
+			candidates = append(candidates, candidate)
+		}
+	}
+
+	suggestion, ok := closestName(candidates, name)
+	if !ok {
+		return
+	}
+
+	pass.Report(analysis.Diagnostic{
+		Pos:      call.Pos(),
+		End:      call.End(),
+		Category: "reflect-lookup",
+		Message:  fmt.Sprintf(reflectRegistryMsgFormat, fn.Name(), name, pkg) + fmt.Sprintf(didYouMeanSuffix, suggestion),
+	})
+}
+
+// protoPackageOf returns the longest known proto package the full name belongs to.
+// The names without dots belong to the empty package.
+func protoPackageOf(resolver *messageResolver, fullName string) (string, bool) {
+	for i := strings.LastIndex(fullName, "."); i > 0; i = strings.LastIndex(fullName[:i], ".") {
+		if resolver.hasPackage(fullName[:i]) {
+			return fullName[:i], true
+		}
+	}
+
+	if !strings.Contains(fullName, ".") && resolver.hasPackage("") {
+		return "", true
+	}
+
+	return "", false
+}
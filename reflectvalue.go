@@ -0,0 +1,313 @@
+package protogetter
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+const (
+	reflectKindMsgFormat        = "%s does not match field %s of kind %s"
+	reflectCardinalityMsgFormat = "%s does not match %s field %s, use %s"
+	reflectMessageMsgFormat     = "%s of message %s does not match field %s of message %s"
+)
+
+// reflectValueKinds maps the protoreflect.ValueOf* constructors and the protoreflect.Value accessors
+// to the field kinds they accept. Lists and maps are checked by the cardinality.
+var reflectValueKinds = map[string][]descriptorpb.FieldDescriptorProto_Type{
+	"ValueOfBool":    {descriptorpb.FieldDescriptorProto_TYPE_BOOL},
+	"ValueOfInt32":   {descriptorpb.FieldDescriptorProto_TYPE_INT32, descriptorpb.FieldDescriptorProto_TYPE_SINT32, descriptorpb.FieldDescriptorProto_TYPE_SFIXED32},
+	"ValueOfInt64":   {descriptorpb.FieldDescriptorProto_TYPE_INT64, descriptorpb.FieldDescriptorProto_TYPE_SINT64, descriptorpb.FieldDescriptorProto_TYPE_SFIXED64},
+	"ValueOfUint32":  {descriptorpb.FieldDescriptorProto_TYPE_UINT32, descriptorpb.FieldDescriptorProto_TYPE_FIXED32},
+	"ValueOfUint64":  {descriptorpb.FieldDescriptorProto_TYPE_UINT64, descriptorpb.FieldDescriptorProto_TYPE_FIXED64},
+	"ValueOfFloat32": {descriptorpb.FieldDescriptorProto_TYPE_FLOAT},
+	"ValueOfFloat64": {descriptorpb.FieldDescriptorProto_TYPE_DOUBLE},
+	"ValueOfString":  {descriptorpb.FieldDescriptorProto_TYPE_STRING},
+	"ValueOfBytes":   {descriptorpb.FieldDescriptorProto_TYPE_BYTES},
+	"ValueOfEnum":    {descriptorpb.FieldDescriptorProto_TYPE_ENUM},
+	"ValueOfMessage": {descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, descriptorpb.FieldDescriptorProto_TYPE_GROUP},
+	"ValueOfList":    nil,
+	"ValueOfMap":     nil,
+
+	"Bool": {descriptorpb.FieldDescriptorProto_TYPE_BOOL},
+	"Int": {
+		descriptorpb.FieldDescriptorProto_TYPE_INT32, descriptorpb.FieldDescriptorProto_TYPE_SINT32, descriptorpb.FieldDescriptorProto_TYPE_SFIXED32,
+		descriptorpb.FieldDescriptorProto_TYPE_INT64, descriptorpb.FieldDescriptorProto_TYPE_SINT64, descriptorpb.FieldDescriptorProto_TYPE_SFIXED64,
+	},
+	"Uint": {
+		descriptorpb.FieldDescriptorProto_TYPE_UINT32, descriptorpb.FieldDescriptorProto_TYPE_FIXED32,
+		descriptorpb.FieldDescriptorProto_TYPE_UINT64, descriptorpb.FieldDescriptorProto_TYPE_FIXED64,
+	},
+	"Float":   {descriptorpb.FieldDescriptorProto_TYPE_FLOAT, descriptorpb.FieldDescriptorProto_TYPE_DOUBLE},
+	"Bytes":   {descriptorpb.FieldDescriptorProto_TYPE_BYTES},
+	"Enum":    {descriptorpb.FieldDescriptorProto_TYPE_ENUM},
+	"Message": {descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, descriptorpb.FieldDescriptorProto_TYPE_GROUP},
+	"List":    nil,
+	"Map":     nil,
+}
+
+// reflectField is a field descriptor resolved statically, like `md.Fields().ByName("foo")`.
+type reflectField struct {
+	msg   *types.Named
+	name  string
+	field *descriptorpb.FieldDescriptorProto
+}
+
+// checkReflectValues checks the values of the protoreflect `Set(fd, v)` and `Get(fd)` calls
+// against the kind and the cardinality of the field, when the field descriptor is resolved statically:
+// `m.Set(fd, protoreflect.ValueOfString(s))` of an int32 field, `m.Get(fd).Int()` of a string field,
+// or `m.Set(fd, protoreflect.ValueOfMessage(other.ProtoReflect()))` of a field with another message type.
+func checkReflectValues(pass *analysis.Pass, ins *inspector.Inspector) {
+	files := make(generatedFiles)
+	msgVars := make(map[types.Object]types.Type)
+	fieldVars := make(map[types.Object]reflectField)
+
+	addVar := func(ident *ast.Ident, value ast.Expr) {
+		addReflectVar(pass.TypesInfo, msgVars, ident, value)
+
+		obj := pass.TypesInfo.ObjectOf(ident)
+		if obj == nil {
+			return
+		}
+
+		if value == nil {
+			delete(fieldVars, obj)
+			return
+		}
+
+		if field, ok := resolveReflectField(pass, files, msgVars, fieldVars, value); ok {
+			fieldVars[obj] = field
+		} else {
+			delete(fieldVars, obj)
+		}
+	}
+
+	nodeTypes := []ast.Node{
+		(*ast.AssignStmt)(nil),
+		(*ast.ValueSpec)(nil),
+		(*ast.CallExpr)(nil),
+	}
+
+	ins.Preorder(nodeTypes, func(n ast.Node) {
+		switch x := n.(type) {
+		case *ast.AssignStmt:
+			for i, lhs := range x.Lhs {
+				if ident, ok := lhs.(*ast.Ident); ok {
+					addVar(ident, assignedValue(x, i))
+				}
+			}
+
+		case *ast.ValueSpec:
+			if len(x.Names) != len(x.Values) {
+				return
+			}
+
+			for i, name := range x.Names {
+				addVar(name, x.Values[i])
+			}
+
+		case *ast.CallExpr:
+			fn, ok := typeutil.Callee(pass.TypesInfo, x).(*types.Func)
+			if !ok || fn.Pkg() == nil || fn.Pkg().Path() != protoreflectPkgPath {
+				return
+			}
+
+			switch recv := reflectRecvName(fn); {
+			case recv == "Message" && fn.Name() == "Set" && len(x.Args) == 2:
+				field, ok := resolveReflectField(pass, files, msgVars, fieldVars, x.Args[0])
+				if !ok {
+					return
+				}
+
+				value, ok := ast.Unparen(x.Args[1]).(*ast.CallExpr)
+				if !ok {
+					return
+				}
+
+				ctor, ok := typeutil.Callee(pass.TypesInfo, value).(*types.Func)
+				if !ok || ctor.Pkg() == nil || ctor.Pkg().Path() != protoreflectPkgPath || reflectRecvName(ctor) != "" {
+					return
+				}
+
+				if _, ok := reflectValueKinds[ctor.Name()]; !ok {
+					return
+				}
+
+				if reportReflectValue(pass, value, "protoreflect."+ctor.Name(), ctor.Name(), field) {
+					return
+				}
+
+				if ctor.Name() == "ValueOfMessage" && len(value.Args) == 1 {
+					checkReflectMessageValue(pass, value, msgVars, field)
+				}
+
+			case recv == "Value" && len(x.Args) == 0:
+				if _, ok := reflectValueKinds[fn.Name()]; !ok {
+					return
+				}
+
+				sel, ok := x.Fun.(*ast.SelectorExpr)
+				if !ok {
+					return
+				}
+
+				get, ok := ast.Unparen(sel.X).(*ast.CallExpr)
+				if !ok || len(get.Args) != 1 {
+					return
+				}
+
+				getFn, ok := typeutil.Callee(pass.TypesInfo, get).(*types.Func)
+				if !ok || getFn.Name() != "Get" || reflectRecvName(getFn) != "Message" {
+					return
+				}
+
+				if field, ok := resolveReflectField(pass, files, msgVars, fieldVars, get.Args[0]); ok {
+					reportReflectValue(pass, x, "Value."+fn.Name(), fn.Name(), field)
+				}
+			}
+		}
+	})
+}
+
+// resolveReflectField returns the field of the descriptor expression, a lookup with a constant argument
+// or a variable assigned from it.
+func resolveReflectField(pass *analysis.Pass, files generatedFiles, msgVars map[types.Object]types.Type, fieldVars map[types.Object]reflectField, expr ast.Expr) (reflectField, bool) {
+	switch x := ast.Unparen(expr).(type) {
+	case *ast.Ident:
+		field, ok := fieldVars[pass.TypesInfo.ObjectOf(x)]
+		return field, ok
+
+	case *ast.CallExpr:
+		lookup, ok := resolveDescriptorLookup(pass, files, msgVars, x)
+		if !ok {
+			return reflectField{}, false
+		}
+
+		fd := lookup.field()
+		if fd == nil {
+			return reflectField{}, false
+		}
+
+		return reflectField{msg: lookup.msg, name: lookup.msgName + "." + fd.GetName(), field: fd}, true
+	}
+
+	return reflectField{}, false
+}
+
+// reportReflectValue reports the constructor or the accessor not matching the field
+// and returns whether it was reported.
+func reportReflectValue(pass *analysis.Pass, node ast.Node, what, name string, field reflectField) bool {
+	kinds := reflectValueKinds[name]
+	isList := name == "ValueOfList" || name == "List"
+	isMap := name == "ValueOfMap" || name == "Map"
+
+	fieldIsMap := isMapField(field)
+	fieldIsList := field.field.GetLabel() == descriptorpb.FieldDescriptorProto_LABEL_REPEATED && !fieldIsMap
+
+	var msg string
+	switch {
+	case fieldIsList && !isList:
+		msg = fmt.Sprintf(reflectCardinalityMsgFormat, what, "repeated", field.name, reflectValueName(name, "List"))
+	case fieldIsMap && !isMap:
+		msg = fmt.Sprintf(reflectCardinalityMsgFormat, what, "map", field.name, reflectValueName(name, "Map"))
+	case !fieldIsList && isList, !fieldIsMap && isMap:
+		msg = fmt.Sprintf(reflectKindMsgFormat, what, field.name, reflectKindName(field.field.GetType()))
+	case !fieldIsList && !fieldIsMap && !containsKind(kinds, field.field.GetType()):
+		msg = fmt.Sprintf(reflectKindMsgFormat, what, field.name, reflectKindName(field.field.GetType()))
+	default:
+		return false
+	}
+
+	pass.Report(analysis.Diagnostic{
+		Pos:      node.Pos(),
+		End:      node.End(),
+		Category: "reflect-value",
+		Message:  msg,
+	})
+
+	return true
+}
+
+// checkReflectMessageValue reports `protoreflect.ValueOfMessage(other.ProtoReflect())` of a message
+// other than the type of the field.
+func checkReflectMessageValue(pass *analysis.Pass, value *ast.CallExpr, msgVars map[types.Object]types.Type, field reflectField) {
+	valueType := reflectMessageType(pass.TypesInfo, msgVars, value.Args[0])
+	if valueType == nil {
+		return
+	}
+
+	fieldType := getterResult(field.msg, goCamelCase(field.field.GetName()))
+	if fieldType == nil || types.Identical(fieldType, valueType) {
+		return
+	}
+
+	file := fileOf(pass, value.Pos())
+	pass.Report(analysis.Diagnostic{
+		Pos:      value.Pos(),
+		End:      value.End(),
+		Category: "reflect-value",
+		Message: fmt.Sprintf(reflectMessageMsgFormat,
+			"protoreflect.ValueOfMessage", caseTypeString(pass.Pkg, file, valueType), field.name, caseTypeString(pass.Pkg, file, fieldType)),
+	})
+}
+
+// reflectRecvName returns the name of the receiver type of the method, or an empty string for a function.
+func reflectRecvName(fn *types.Func) string {
+	recv := fn.Type().(*types.Signature).Recv()
+	if recv == nil {
+		return ""
+	}
+
+	named, ok := namedType(recv.Type())
+	if !ok {
+		return ""
+	}
+
+	return named.Obj().Name()
+}
+
+// reflectValueName returns the constructor or the accessor of the same family as the name, like `protoreflect.ValueOfList`.
+func reflectValueName(name, kind string) string {
+	if strings.HasPrefix(name, "ValueOf") {
+		return "protoreflect.ValueOf" + kind
+	}
+
+	return "Value." + kind
+}
+
+// isMapField reports whether the field is a map, generated as a Go map.
+func isMapField(field reflectField) bool {
+	if field.field.GetLabel() != descriptorpb.FieldDescriptorProto_LABEL_REPEATED ||
+		field.field.GetType() != descriptorpb.FieldDescriptorProto_TYPE_MESSAGE {
+		return false
+	}
+
+	t := getterResult(field.msg, goCamelCase(field.field.GetName()))
+	if t == nil {
+		return false
+	}
+
+	_, ok := t.Underlying().(*types.Map)
+	return ok
+}
+
+func containsKind(kinds []descriptorpb.FieldDescriptorProto_Type, kind descriptorpb.FieldDescriptorProto_Type) bool {
+	for _, k := range kinds {
+		if k == kind {
+			return true
+		}
+	}
+
+	return false
+}
+
+// reflectKindName returns the name of the kind as protoreflect.Kind prints it, like `int32`.
+func reflectKindName(kind descriptorpb.FieldDescriptorProto_Type) string {
+	return strings.ToLower(strings.TrimPrefix(kind.String(), "TYPE_"))
+}
@@ -0,0 +1,309 @@
+package protogetter
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+const (
+	repeatedIndexMsgFormat = "index of repeated field %s is not checked with len(), the getter returns an empty slice when the field is not set"
+
+	// stillUnsafeIndexSuffix marks the getter fixes that still index a repeated field without a len check.
+	stillUnsafeIndexSuffix = " (still unsafe: the index is not checked with len())"
+)
+
+// checkRepeatedIndexes reports indexing of repeated field getter results, like `t.GetList()[0]`,
+// without a dominating len check. The getters turn a nil parent into an empty slice,
+// so a nil pointer panic becomes an index out of range panic.
+func checkRepeatedIndexes(pass *analysis.Pass, ins *inspector.Inspector) {
+	nodeTypes := []ast.Node{
+		(*ast.IndexExpr)(nil),
+	}
+
+	ins.WithStack(nodeTypes, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+
+		index := n.(*ast.IndexExpr)
+		if _, ok := ast.Unparen(index.X).(*ast.CallExpr); !ok {
+			return true
+		}
+
+		if !isRepeatedFieldRead(pass.TypesInfo, index.X) || isLenGuarded(pass.TypesInfo, index, stack) {
+			return true
+		}
+
+		pass.Report(analysis.Diagnostic{
+			Pos:      index.Pos(),
+			End:      index.End(),
+			Category: "repeated-index",
+			Message:  fmt.Sprintf(repeatedIndexMsgFormat, formatNode(index.X)),
+		})
+
+		return true
+	})
+}
+
+// hasUnguardedRepeatedIndex reports whether the node indexes a repeated field, read directly
+// or with a getter, without a len check. It is used to mark the getter fixes of such nodes.
+func hasUnguardedRepeatedIndex(pass *analysis.Pass, node ast.Node) bool {
+	file := fileOf(pass, node.Pos())
+	if file == nil {
+		return false
+	}
+
+	path, _ := astutil.PathEnclosingInterval(file, node.Pos(), node.End())
+
+	// The path goes from the node to the file, the stack goes the other way.
+	stack := make([]ast.Node, 0, len(path))
+	for i := len(path) - 1; i >= 0; i-- {
+		stack = append(stack, path[i])
+	}
+
+	unguarded := false
+	ast.Inspect(node, func(n ast.Node) bool {
+		if unguarded || n == nil {
+			return false
+		}
+
+		index, ok := n.(*ast.IndexExpr)
+		if ok && isRepeatedFieldRead(pass.TypesInfo, index.X) && !isLenGuarded(pass.TypesInfo, index, append(stack, index)) {
+			unguarded = true
+		}
+
+		return true
+	})
+
+	return unguarded
+}
+
+// isRepeatedFieldRead reports whether the expression is a repeated field of a proto message,
+// `t.List` or `t.GetList()`.
+func isRepeatedFieldRead(info *types.Info, expr ast.Expr) bool {
+	t := info.TypeOf(expr)
+	if t == nil {
+		return false
+	}
+
+	if _, ok := t.Underlying().(*types.Slice); !ok {
+		return false
+	}
+
+	switch x := ast.Unparen(expr).(type) {
+	case *ast.SelectorExpr:
+		// bytes fields are slices too, but they are not repeated.
+		return isProtoMessage(info, x.X) && !isByteSlice(t)
+
+	case *ast.CallExpr:
+		fun, ok := x.Fun.(*ast.SelectorExpr)
+		return ok && len(x.Args) == 0 && isProtoMessage(info, fun.X) && !isByteSlice(t)
+	}
+
+	return false
+}
+
+func isByteSlice(t types.Type) bool {
+	slice, ok := t.Underlying().(*types.Slice)
+	if !ok {
+		return false
+	}
+
+	basic, ok := slice.Elem().Underlying().(*types.Basic)
+	return ok && basic.Kind() == types.Byte
+}
+
+// isLenGuarded reports whether the index is dominated by a len check of the indexed field:
+// an enclosing if, case or && / || whose branch holding the index is only taken for a long enough slice,
+// a for with `len(x)`, ranging over the field, or an earlier if for a too short slice that returns.
+func isLenGuarded(info *types.Info, index *ast.IndexExpr, stack []ast.Node) bool {
+	path := accessPath(info, index.X)
+
+	for i := len(stack) - 2; i >= 0; i-- {
+		switch x := stack[i].(type) {
+		case *ast.IfStmt:
+			if stack[i+1] == x.Body && isLongLenCheck(info, x.Cond, path) {
+				return true
+			}
+
+			if stack[i+1] == x.Else && isShortLenCheck(info, x.Cond, path) {
+				return true
+			}
+
+		case *ast.ForStmt:
+			if mentionsLen(info, x.Cond, path) {
+				return true
+			}
+
+		case *ast.RangeStmt:
+			if accessPath(info, x.X) == path {
+				return true
+			}
+
+		case *ast.CaseClause:
+			for _, expr := range x.List {
+				if isLongLenCheck(info, expr, path) {
+					return true
+				}
+			}
+
+		case *ast.BinaryExpr:
+			if x.Op == token.LAND && stack[i+1] == x.Y && isLongLenCheck(info, x.X, path) {
+				return true
+			}
+
+			if x.Op == token.LOR && stack[i+1] == x.Y && isShortLenCheck(info, x.X, path) {
+				return true
+			}
+		}
+	}
+
+	body := enclosingFuncBody(stack)
+	if body == nil {
+		return false
+	}
+
+	guarded := false
+	ast.Inspect(body, func(n ast.Node) bool {
+		if guarded || n == nil || n.Pos() >= index.Pos() {
+			return false
+		}
+
+		ifStmt, ok := n.(*ast.IfStmt)
+		if ok && ifStmt.End() < index.Pos() && isShortLenCheck(info, ifStmt.Cond, path) && isTerminating(ifStmt.Body) {
+			guarded = true
+		}
+
+		return true
+	})
+
+	return guarded
+}
+
+// isShortLenCheck reports whether the condition only holds for a too short slice,
+// like `len(x) == 0` or `len(x) < 2`, or a || chain containing it.
+func isShortLenCheck(info *types.Info, cond ast.Expr, path string) bool {
+	switch x := ast.Unparen(cond).(type) {
+	case *ast.UnaryExpr:
+		return x.Op == token.NOT && isLongLenCheck(info, x.X, path)
+
+	case *ast.BinaryExpr:
+		switch x.Op {
+		case token.LOR:
+			return isShortLenCheck(info, x.X, path) || isShortLenCheck(info, x.Y, path)
+		case token.LAND:
+			return isShortLenCheck(info, x.X, path) && isShortLenCheck(info, x.Y, path)
+		}
+
+		op, other, ok := lenComparison(info, x, path)
+		if !ok {
+			return false
+		}
+
+		return (op == token.EQL && isZeroConstant(info, other)) || op == token.LSS || op == token.LEQ
+	}
+
+	return false
+}
+
+// isLongLenCheck reports whether the condition only holds for a slice long enough to be indexed,
+// like `len(x) > 0`, `len(x) != 0` or `i < len(x)`, or a && chain containing it.
+func isLongLenCheck(info *types.Info, cond ast.Expr, path string) bool {
+	switch x := ast.Unparen(cond).(type) {
+	case *ast.UnaryExpr:
+		return x.Op == token.NOT && isShortLenCheck(info, x.X, path)
+
+	case *ast.BinaryExpr:
+		switch x.Op {
+		case token.LAND:
+			return isLongLenCheck(info, x.X, path) || isLongLenCheck(info, x.Y, path)
+		case token.LOR:
+			return isLongLenCheck(info, x.X, path) && isLongLenCheck(info, x.Y, path)
+		}
+
+		op, other, ok := lenComparison(info, x, path)
+		if !ok {
+			return false
+		}
+
+		switch op {
+		case token.GTR:
+			return true
+		case token.GEQ, token.EQL:
+			return !isZeroConstant(info, other)
+		case token.NEQ:
+			return isZeroConstant(info, other)
+		}
+	}
+
+	return false
+}
+
+// lenComparison checks that the expression compares `len(x)` of the path and returns the operator
+// as if `len(x)` was on the left, and the other operand.
+func lenComparison(info *types.Info, x *ast.BinaryExpr, path string) (token.Token, ast.Expr, bool) {
+	switch {
+	case isLenCall(info, x.X, path):
+		return x.Op, x.Y, true
+
+	case isLenCall(info, x.Y, path):
+		switch x.Op {
+		case token.LSS:
+			return token.GTR, x.X, true
+		case token.LEQ:
+			return token.GEQ, x.X, true
+		case token.GTR:
+			return token.LSS, x.X, true
+		case token.GEQ:
+			return token.LEQ, x.X, true
+		}
+
+		return x.Op, x.X, true
+	}
+
+	return token.ILLEGAL, nil, false
+}
+
+// mentionsLen reports whether the expression or statement contains `len(x)` of the path.
+func mentionsLen(info *types.Info, node ast.Node, path string) bool {
+	if node == nil {
+		return false
+	}
+
+	found := false
+	ast.Inspect(node, func(n ast.Node) bool {
+		if found {
+			return false
+		}
+
+		if expr, ok := n.(ast.Expr); ok && isLenCall(info, expr, path) {
+			found = true
+		}
+
+		return true
+	})
+
+	return found
+}
+
+// isLenCall reports whether the expression is `len(x)` of the path.
+func isLenCall(info *types.Info, expr ast.Expr, path string) bool {
+	call, ok := ast.Unparen(expr).(*ast.CallExpr)
+	if !ok || len(call.Args) != 1 {
+		return false
+	}
+
+	ident, ok := call.Fun.(*ast.Ident)
+	if !ok || ident.Name != "len" {
+		return false
+	}
+
+	_, ok = info.Uses[ident].(*types.Builtin)
+	return ok && accessPath(info, call.Args[0]) == path
+}
@@ -0,0 +1,235 @@
+package protogetter
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+const (
+	serializerMsgFormat         = "avoid using %s with proto message %s, use %s instead"
+	serializerEmbeddedMsgFormat = "avoid using %s with %s, it embeds proto message %s, use %s instead"
+
+	protojsonPkgPath = "google.golang.org/protobuf/encoding/protojson"
+)
+
+// serializer describes an encoder or a decoder that does not know about proto messages.
+type serializer struct {
+	// arg is the index of the argument holding the message.
+	arg int
+	// replacement is the proto aware function to suggest.
+	replacement string
+	// fix is the name of the protojson function that can replace the call as is, if any.
+	fix string
+}
+
+// serializers maps a package path to the serializer functions and methods by name.
+// Methods are keyed as `Type.Method`.
+var serializers = map[string]map[string]serializer{
+	"encoding/json": {
+		"Marshal":        {arg: 0, replacement: "protojson.Marshal", fix: "Marshal"},
+		"MarshalIndent":  {arg: 0, replacement: "protojson.MarshalOptions"},
+		"Unmarshal":      {arg: 1, replacement: "protojson.Unmarshal", fix: "Unmarshal"},
+		"Encoder.Encode": {arg: 0, replacement: "protojson.Marshal"},
+		"Decoder.Decode": {arg: 0, replacement: "protojson.Unmarshal"},
+	},
+	"encoding/xml": {
+		"Marshal":        {arg: 0, replacement: "prototext.Marshal"},
+		"MarshalIndent":  {arg: 0, replacement: "prototext.MarshalOptions"},
+		"Unmarshal":      {arg: 1, replacement: "prototext.Unmarshal"},
+		"Encoder.Encode": {arg: 0, replacement: "prototext.Marshal"},
+		"Decoder.Decode": {arg: 0, replacement: "prototext.Unmarshal"},
+	},
+	"encoding/gob": {
+		"Encoder.Encode": {arg: 0, replacement: "proto.Marshal"},
+		"Decoder.Decode": {arg: 0, replacement: "proto.Unmarshal"},
+	},
+	"gopkg.in/yaml.v2": yamlSerializers,
+	"gopkg.in/yaml.v3": yamlSerializers,
+	"sigs.k8s.io/yaml": yamlSerializers,
+}
+
+var yamlSerializers = map[string]serializer{
+	"Marshal":        {arg: 0, replacement: "protojson.Marshal"},
+	"Unmarshal":      {arg: 1, replacement: "protojson.Unmarshal"},
+	"Encoder.Encode": {arg: 0, replacement: "protojson.Marshal"},
+	"Decoder.Decode": {arg: 0, replacement: "protojson.Unmarshal"},
+}
+
+// checkSerializers reports proto messages, and structs embedding them, passed to encoders that
+// are not aware of proto messages. Such encoders use the Go field names instead of the proto names,
+// don't handle oneofs and enums, and touch the internal fields of the messages.
+func checkSerializers(pass *analysis.Pass, ins *inspector.Inspector) {
+	nodeTypes := []ast.Node{
+		(*ast.CallExpr)(nil),
+	}
+
+	ins.Preorder(nodeTypes, func(n ast.Node) {
+		call := n.(*ast.CallExpr)
+
+		fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+		if !ok || fn.Pkg() == nil {
+			return
+		}
+
+		name := fn.Name()
+		if recv := fn.Signature().Recv(); recv != nil {
+			named, ok := namedType(recv.Type())
+			if !ok {
+				return
+			}
+			name = named.Obj().Name() + "." + name
+		}
+
+		s, ok := serializers[fn.Pkg().Path()][name]
+		if !ok || len(call.Args) <= s.arg {
+			return
+		}
+
+		arg := call.Args[s.arg]
+		what := fn.Pkg().Name() + "." + name
+		if fn.Signature().Recv() != nil {
+			what = fmt.Sprintf("(*%s.%s", fn.Pkg().Name(), strings.Replace(name, ".", ").", 1))
+		}
+
+		t := pass.TypesInfo.TypeOf(arg)
+		if isProtoMessageType(t) {
+			reportSerializer(pass, call, arg, what, s)
+			return
+		}
+
+		if embedded, ok := embeddedProtoMessage(t); ok {
+			pass.Report(analysis.Diagnostic{
+				Pos:      call.Pos(),
+				End:      call.End(),
+				Category: "serializer",
+				Message:  fmt.Sprintf(serializerEmbeddedMsgFormat, what, formatNode(arg), embedded, s.replacement),
+			})
+		}
+	})
+}
+
+// embeddedProtoMessage returns the name of the proto message directly embedded
+// into the struct (or a pointer to the struct, as decoders take `&v`).
+func embeddedProtoMessage(t types.Type) (string, bool) {
+	if t == nil {
+		return "", false
+	}
+
+	for i := 0; i < 2; i++ {
+		if ptr, ok := t.Underlying().(*types.Pointer); ok {
+			t = ptr.Elem()
+		}
+	}
+
+	st, ok := t.Underlying().(*types.Struct)
+	if !ok || isProtoMessageType(t) {
+		return "", false
+	}
+
+	for i := 0; i < st.NumFields(); i++ {
+		field := st.Field(i)
+		if field.Embedded() && isProtoMessageType(field.Type()) {
+			return field.Name(), true
+		}
+	}
+
+	return "", false
+}
+
+func reportSerializer(pass *analysis.Pass, call *ast.CallExpr, arg ast.Expr, what string, s serializer) {
+	msg := fmt.Sprintf(serializerMsgFormat, what, formatNode(arg), s.replacement)
+	diag := analysis.Diagnostic{
+		Pos:      call.Pos(),
+		End:      call.End(),
+		Category: "serializer",
+		Message:  msg,
+	}
+
+	// protojson accepts only v2 messages. Its output differs from encoding/json: it uses the proto JSON names,
+	// writes int64 as strings and enums as names, so the fixed call produces another JSON.
+	file := fileOf(pass, call.Pos())
+	pkg, ok := serializerPkgName(pass, call)
+	if !ok || file == nil || !canFixSerializer(pass, arg, s) {
+		pass.Report(diag)
+		return
+	}
+
+	name, edits := addImport(file, protojsonPkgPath)
+	edits = append([]analysis.TextEdit{
+		{
+			Pos:     call.Fun.Pos(),
+			End:     call.Fun.End(),
+			NewText: []byte(name + "." + s.fix),
+		},
+	}, edits...)
+
+	if spec, ok := onlyUseOfImport(pass, file, pkg); ok {
+		edits = append(edits, analysis.TextEdit{
+			Pos: spec.Pos(),
+			End: spec.End(),
+		})
+	}
+
+	diag.SuggestedFixes = []analysis.SuggestedFix{
+		{
+			Message:   msg,
+			TextEdits: edits,
+		},
+	}
+	pass.Report(diag)
+}
+
+// canFixSerializer reports whether the call of the serializer with the argument can be switched to protojson.
+func canFixSerializer(pass *analysis.Pass, arg ast.Expr, s serializer) bool {
+	named, _ := namedType(pass.TypesInfo.TypeOf(arg))
+	return s.fix != "" &&
+		isProtoMessagePointer(pass.TypesInfo.TypeOf(arg)) &&
+		namedHasMethod(named, "ProtoReflect")
+}
+
+// serializerPkgName returns the imported package the serializer is called through, like `json` in `json.Marshal(m)`.
+func serializerPkgName(pass *analysis.Pass, call *ast.CallExpr) (*types.PkgName, bool) {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return nil, false
+	}
+
+	ident, ok := sel.X.(*ast.Ident)
+	if !ok {
+		return nil, false
+	}
+
+	pkg, ok := pass.TypesInfo.Uses[ident].(*types.PkgName)
+	return pkg, ok
+}
+
+// onlyUseOfImport returns the import of the package when the call is its only use in the file,
+// so the fix replacing the call has to remove the import. With other uses left, the import stays:
+// a single applied fix must not break the other calls, the unused import is left to goimports.
+func onlyUseOfImport(pass *analysis.Pass, file *ast.File, pkg *types.PkgName) (*ast.ImportSpec, bool) {
+	uses := 0
+	ast.Inspect(file, func(n ast.Node) bool {
+		if ident, ok := n.(*ast.Ident); ok && pass.TypesInfo.Uses[ident] == pkg {
+			uses++
+		}
+		return true
+	})
+
+	if uses != 1 {
+		return nil, false
+	}
+
+	for _, spec := range file.Imports {
+		if pass.TypesInfo.PkgNameOf(spec) == pkg {
+			return spec, true
+		}
+	}
+
+	return nil, false
+}
@@ -0,0 +1,364 @@
+package protogetter
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+const (
+	aliasMutationMsgFormat = "%s modifies the backing array of repeated field %s through alias %s created at line %d"
+	aliasStoreMsgFormat    = "repeated field %s is stored into %s without a copy, both messages share the backing array"
+	aliasAppendMsgFormat   = "append to repeated field %s is stored into %s, it may write into the backing array of %s"
+	aliasCreatedSuffix     = " (alias %s created at line %d)"
+)
+
+// sliceAlias is a variable holding a repeated field of a message.
+type sliceAlias struct {
+	// site is the assignment creating the alias.
+	site ast.Node
+	// source is the repeated field read, like `t.GetItems()` or `t.Items[1:]`.
+	source ast.Expr
+	// owner is the access path of the message owning the field.
+	owner string
+	// cleared marks a later assignment of the variable to something else, like `s = slices.Clone(s)`.
+	cleared bool
+}
+
+// sliceAliases holds the assignments of the variables that alias a repeated field at some point, in source order.
+type sliceAliases map[types.Object][]sliceAlias
+
+// at returns the alias the variable holds at the position, if its last assignment before it is from a repeated field.
+func (a sliceAliases) at(obj types.Object, pos token.Pos) (sliceAlias, bool) {
+	var (
+		last  sliceAlias
+		found bool
+	)
+
+	for _, alias := range a[obj] {
+		if alias.site.Pos() >= pos {
+			break
+		}
+		last, found = alias, true
+	}
+
+	return last, found && !last.cleared
+}
+
+// sliceMutators are the functions mutating the slice passed as the first argument.
+var sliceMutators = map[string]map[string]struct{}{
+	"sort": {
+		"Slice":       {},
+		"SliceStable": {},
+		"Sort":        {},
+		"Stable":      {},
+		"Strings":     {},
+		"Ints":        {},
+		"Float64s":    {},
+	},
+	"slices": {
+		"Sort":           {},
+		"SortFunc":       {},
+		"SortStableFunc": {},
+		"Reverse":        {},
+	},
+}
+
+// checkSliceAliasing follows repeated field values, read with getters or directly, into mutations
+// through a variable, appends stored into another message and storing them into another message.
+// All of them silently share the backing array between the messages.
+func checkSliceAliasing(pass *analysis.Pass, ins *inspector.Inspector) {
+	aliases := collectSliceAliases(pass, ins)
+
+	nodeTypes := []ast.Node{
+		(*ast.AssignStmt)(nil),
+		(*ast.IncDecStmt)(nil),
+		(*ast.CallExpr)(nil),
+		(*ast.CompositeLit)(nil),
+	}
+
+	ins.Preorder(nodeTypes, func(n ast.Node) {
+		switch x := n.(type) {
+		case *ast.AssignStmt:
+			for i, lhs := range x.Lhs {
+				if index, ok := ast.Unparen(lhs).(*ast.IndexExpr); ok {
+					reportAliasMutation(pass, aliases, index.X, lhs)
+				}
+
+				if x.Tok != token.DEFINE && len(x.Lhs) == len(x.Rhs) {
+					if owner, ok := repeatedFieldOwner(pass.TypesInfo, lhs); ok {
+						reportAliasStore(pass, aliases, x.Rhs[i], lhs, owner)
+					}
+				}
+			}
+
+		case *ast.IncDecStmt:
+			if index, ok := ast.Unparen(x.X).(*ast.IndexExpr); ok {
+				reportAliasMutation(pass, aliases, index.X, x)
+			}
+
+		case *ast.CallExpr:
+			if fn, ok := typeutil.Callee(pass.TypesInfo, x).(*types.Builtin); ok && fn.Name() == "copy" && len(x.Args) == 2 {
+				reportAliasMutation(pass, aliases, x.Args[0], x)
+				return
+			}
+
+			if fn, ok := typeutil.Callee(pass.TypesInfo, x).(*types.Func); ok && fn.Pkg() != nil && len(x.Args) > 0 {
+				if _, ok := sliceMutators[fn.Pkg().Path()][fn.Name()]; ok {
+					reportAliasMutation(pass, aliases, x.Args[0], x)
+					return
+				}
+			}
+
+			// `other.SetItems(t.GetItems())` of the opaque API.
+			fun, ok := x.Fun.(*ast.SelectorExpr)
+			if ok && len(x.Args) == 1 && strings.HasPrefix(fun.Sel.Name, "Set") && isProtoMessage(pass.TypesInfo, fun.X) {
+				reportAliasStore(pass, aliases, x.Args[0], x, accessPath(pass.TypesInfo, fun.X))
+			}
+
+		case *ast.CompositeLit:
+			if !isProtoMessageType(pass.TypesInfo.TypeOf(x)) {
+				return
+			}
+
+			// A new message never owns the field it is created from.
+			for _, elt := range x.Elts {
+				if kv, ok := elt.(*ast.KeyValueExpr); ok {
+					reportAliasStore(pass, aliases, kv.Value, kv, "")
+				}
+			}
+		}
+	})
+}
+
+// collectSliceAliases finds the variables assigned from a repeated field, like `s := t.GetItems()`,
+// and their later assignments, which clear the alias unless they reslice it, like `s = s[1:]`.
+func collectSliceAliases(pass *analysis.Pass, ins *inspector.Inspector) sliceAliases {
+	aliases := make(sliceAliases)
+
+	add := func(site ast.Node, ident *ast.Ident, value ast.Expr) {
+		obj := pass.TypesInfo.ObjectOf(ident)
+		if obj == nil {
+			return
+		}
+
+		if owner, source, ok := repeatedFieldSource(pass.TypesInfo, value); ok {
+			aliases[obj] = append(aliases[obj], sliceAlias{site: site, source: source, owner: owner})
+			return
+		}
+
+		if _, ok := aliases[obj]; !ok || reslices(pass.TypesInfo, value, obj) {
+			return
+		}
+
+		aliases[obj] = append(aliases[obj], sliceAlias{site: site, cleared: true})
+	}
+
+	nodeTypes := []ast.Node{
+		(*ast.AssignStmt)(nil),
+		(*ast.ValueSpec)(nil),
+	}
+
+	ins.Preorder(nodeTypes, func(n ast.Node) {
+		switch x := n.(type) {
+		case *ast.AssignStmt:
+			for i, lhs := range x.Lhs {
+				ident, ok := lhs.(*ast.Ident)
+				if !ok {
+					continue
+				}
+
+				// `s, err = f()` assigns a new value too.
+				var value ast.Expr
+				if len(x.Lhs) == len(x.Rhs) {
+					value = x.Rhs[i]
+				}
+
+				add(x, ident, value)
+			}
+
+		case *ast.ValueSpec:
+			for i, name := range x.Names {
+				var value ast.Expr
+				if len(x.Names) == len(x.Values) {
+					value = x.Values[i]
+				}
+
+				add(x, name, value)
+			}
+		}
+	})
+
+	return aliases
+}
+
+// reslices reports whether the value is the variable itself, possibly resliced, like `s[1:]`.
+func reslices(info *types.Info, value ast.Expr, obj types.Object) bool {
+	for value != nil {
+		switch x := ast.Unparen(value).(type) {
+		case *ast.SliceExpr:
+			value = x.X
+		case *ast.Ident:
+			return info.ObjectOf(x) == obj
+		default:
+			return false
+		}
+	}
+
+	return false
+}
+
+// repeatedFieldSource checks that the expression reads a repeated field, possibly resliced,
+// and returns the access path of the message owning it.
+func repeatedFieldSource(info *types.Info, expr ast.Expr) (string, ast.Expr, bool) {
+	if expr == nil {
+		return "", nil, false
+	}
+
+	source := ast.Unparen(expr)
+	for {
+		slice, ok := source.(*ast.SliceExpr)
+		if !ok {
+			break
+		}
+		source = ast.Unparen(slice.X)
+	}
+
+	if !isRepeatedFieldRead(info, source) {
+		return "", nil, false
+	}
+
+	switch x := source.(type) {
+	case *ast.SelectorExpr:
+		return accessPath(info, x.X), expr, true
+	case *ast.CallExpr:
+		return accessPath(info, x.Fun.(*ast.SelectorExpr).X), expr, true
+	}
+
+	return "", nil, false
+}
+
+// repeatedFieldOwner checks that the expression is a repeated field written directly, like `other.Items`,
+// and returns the access path of the message owning it.
+func repeatedFieldOwner(info *types.Info, expr ast.Expr) (string, bool) {
+	sel, ok := ast.Unparen(expr).(*ast.SelectorExpr)
+	if !ok || !isRepeatedFieldRead(info, sel) {
+		return "", false
+	}
+
+	return accessPath(info, sel.X), true
+}
+
+// reportAliasMutation reports mutations like `s[i] = v`, `s[i]++` or `sort.Strings(s)` of an alias of a repeated field.
+func reportAliasMutation(pass *analysis.Pass, aliases sliceAliases, slice ast.Expr, node ast.Node) {
+	ident, ok := ast.Unparen(slice).(*ast.Ident)
+	if !ok {
+		return
+	}
+
+	alias, ok := aliases.at(pass.TypesInfo.ObjectOf(ident), node.Pos())
+	if !ok {
+		return
+	}
+
+	what := formatNode(node)
+	if call, ok := node.(*ast.CallExpr); ok {
+		what = formatNode(call.Fun)
+	}
+
+	line := pass.Fset.Position(alias.site.Pos()).Line
+	pass.Report(analysis.Diagnostic{
+		Pos:      node.Pos(),
+		End:      node.End(),
+		Category: "slice-alias",
+		Message:  fmt.Sprintf(aliasMutationMsgFormat, what, formatNode(alias.source), ident.Name, line),
+		Related:  aliasRelated(alias, ident.Name),
+	})
+}
+
+// reportAliasStore reports storing a repeated field, directly, through an alias or appended,
+// into a message other than the one owning the field.
+func reportAliasStore(pass *analysis.Pass, aliases sliceAliases, value ast.Expr, node ast.Node, owner string) {
+	value = ast.Unparen(value)
+
+	var appended bool
+	if call, ok := value.(*ast.CallExpr); ok {
+		fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Builtin)
+		if ok && fn.Name() == "append" && len(call.Args) > 0 {
+			value = ast.Unparen(call.Args[0])
+			appended = true
+		}
+	}
+
+	var (
+		source    ast.Expr
+		srcOwner  string
+		alias     sliceAlias
+		aliasName string
+	)
+
+	if ident, ok := value.(*ast.Ident); ok {
+		var found bool
+		alias, found = aliases.at(pass.TypesInfo.ObjectOf(ident), node.Pos())
+		if !found {
+			return
+		}
+
+		source, srcOwner, aliasName = alias.source, alias.owner, ident.Name
+	} else {
+		var ok bool
+		srcOwner, source, ok = repeatedFieldSource(pass.TypesInfo, value)
+		if !ok {
+			return
+		}
+	}
+
+	if owner != "" && owner == srcOwner {
+		return
+	}
+
+	target := formatNode(node)
+	switch x := node.(type) {
+	case *ast.KeyValueExpr:
+		target = formatNode(x.Key)
+	case *ast.CallExpr:
+		target = formatNode(x.Fun)
+	}
+
+	var msg string
+	if appended {
+		msg = fmt.Sprintf(aliasAppendMsgFormat, formatNode(source), target, formatNode(source))
+	} else {
+		msg = fmt.Sprintf(aliasStoreMsgFormat, formatNode(source), target)
+	}
+
+	diag := analysis.Diagnostic{
+		Pos:      node.Pos(),
+		End:      node.End(),
+		Category: "slice-alias",
+		Message:  msg,
+	}
+
+	if aliasName != "" {
+		diag.Message += fmt.Sprintf(aliasCreatedSuffix, aliasName, pass.Fset.Position(alias.site.Pos()).Line)
+		diag.Related = aliasRelated(alias, aliasName)
+	}
+
+	pass.Report(diag)
+}
+
+func aliasRelated(alias sliceAlias, name string) []analysis.RelatedInformation {
+	return []analysis.RelatedInformation{
+		{
+			Pos:     alias.site.Pos(),
+			End:     alias.site.End(),
+			Message: fmt.Sprintf("alias %s of repeated field %s is created here", name, formatNode(alias.source)),
+		},
+	}
+}
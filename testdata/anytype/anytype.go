@@ -0,0 +1,90 @@
+package anytype
+
+import (
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	pb "github.com/ghostiam/protogetter/testdata/proto"
+)
+
+const embeddedURL = "type.googleapis.com/Embedded"
+
+func testCompare(a *anypb.Any) bool {
+	return a.GetTypeUrl() == "type.googleapis.com/Test" // want `avoid comparing a\.GetTypeUrl\(\) with a type URL string, use a\.MessageIs\(&pb\.Test\{\}\) instead`
+}
+
+func testCompareNot(a *anypb.Any) bool {
+	return embeddedURL != a.GetTypeUrl() // want `avoid comparing a\.GetTypeUrl\(\) with a type URL string, use !a\.MessageIs\(&pb\.Embedded\{\}\) instead`
+}
+
+func testCompareUnknown(a *anypb.Any) bool {
+	return a.GetTypeUrl() == "type.googleapis.com/foo.Unknown" // want `avoid comparing a\.GetTypeUrl\(\) with a type URL string, use a\.MessageIs with a message of this type instead`
+}
+
+func testSwitch(a *anypb.Any) int {
+	switch a.GetTypeUrl() {
+	case "type.googleapis.com/Test": // want `avoid comparing a\.GetTypeUrl\(\) with a type URL string, use a\.MessageIs\(&pb\.Test\{\}\) instead`
+		return 1
+	case "type.googleapis.com/Foo": // want `avoid comparing a\.GetTypeUrl\(\) with a type URL string, use a\.MessageIs\(&pb\.Foo\{\}\) instead`
+		return 2
+	}
+
+	return 0
+}
+
+func testUnmarshalToMismatch(a *anypb.Any) error {
+	if a.MessageIs(&pb.Test{}) {
+		return a.UnmarshalTo(&pb.Embedded{}) // want `UnmarshalTo target \*pb\.Embedded does not match the guard a\.MessageIs\(&pb\.Test\{\}\) for \*pb\.Test`
+	}
+
+	return nil
+}
+
+func testUnmarshalToFuncMismatch(a *anypb.Any) error {
+	switch {
+	case a.MessageIs(&pb.Test{}):
+		m := new(pb.Foo)
+		return anypb.UnmarshalTo(a, m, proto.UnmarshalOptions{}) // want `UnmarshalTo target \*pb\.Foo does not match the guard a\.MessageIs\(&pb\.Test\{\}\) for \*pb\.Test`
+	}
+
+	return nil
+}
+
+func testUnmarshalNewMismatch(a *anypb.Any) *pb.Embedded {
+	m, err := a.UnmarshalNew()
+	if err != nil {
+		return nil
+	}
+
+	if a != nil && a.MessageIs(&pb.Test{}) {
+		return m.(*pb.Embedded) // want `UnmarshalNew target \*pb\.Embedded does not match the guard a\.MessageIs\(&pb\.Test\{\}\) for \*pb\.Test`
+	}
+
+	return nil
+}
+
+func testValid(a, b *anypb.Any) error {
+	if a.MessageIs(&pb.Test{}) {
+		if err := a.UnmarshalTo(&pb.Test{}); err != nil {
+			return err
+		}
+
+		// Another Any is not guarded.
+		return b.UnmarshalTo(&pb.Embedded{})
+	}
+
+	if !a.MessageIs(&pb.Foo{}) {
+		return a.UnmarshalTo(&pb.Embedded{})
+	}
+
+	m, err := anypb.UnmarshalNew(a, proto.UnmarshalOptions{})
+	if err != nil {
+		return err
+	}
+
+	if a.MessageIs(&pb.Foo{}) {
+		_ = m.(*pb.Foo)
+	}
+
+	return nil
+}
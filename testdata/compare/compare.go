@@ -0,0 +1,51 @@
+package compare
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ghostiam/protogetter/testdata/proto"
+)
+
+func testInvalid(tb testing.TB, a, b *proto.Test) {
+	_ = a == b                             // want `avoid comparing proto messages with ==, use proto\.Equal instead`
+	_ = a.GetEmbedded() != b.GetEmbedded() // want `avoid comparing proto messages with !=, use proto\.Equal instead`
+	_ = reflect.DeepEqual(a, b)            // want `avoid comparing proto messages with reflect\.DeepEqual, use proto\.Equal instead`
+	_ = a.String() == b.String()           // want `avoid comparing proto messages with String\(\), use proto\.Equal instead`
+
+	assert.Equal(tb, a, b)                    // want `avoid comparing proto messages with assert\.Equal, use proto\.Equal instead`
+	require.NotEqual(tb, a, b, "must differ") // want `avoid comparing proto messages with require\.NotEqual, use proto\.Equal instead`
+
+	_ = cmp.Diff(a, b)  // want `avoid comparing proto messages with cmp\.Diff, use cmp\.Diff with protocmp\.Transform\(\) instead`
+	_ = cmp.Equal(a, b, // want `avoid comparing proto messages with cmp\.Equal, use cmp\.Equal with protocmp\.Transform\(\) instead`
+		cmp.AllowUnexported(),
+	)
+
+	// Values can't be passed to proto.Equal, so there is no fix.
+	_ = reflect.DeepEqual(*a, *b) // want `avoid comparing proto messages with reflect\.DeepEqual, use proto\.Equal instead`
+}
+
+// Old is a message generated by an old protoc-gen-go, without the v2 API.
+type Old struct{}
+
+func (m *Old) Reset()         { *m = Old{} }
+func (m *Old) String() string { return "" }
+func (*Old) ProtoMessage()    {}
+
+func testInvalidV1(a, b *Old) {
+	// proto.Equal takes only v2 messages, so there is no fix.
+	_ = reflect.DeepEqual(a, b) // want `avoid comparing proto messages with reflect\.DeepEqual, use proto\.Equal instead`
+}
+
+func testValid(tb testing.TB, a, b *proto.Test) {
+	_ = a == nil
+	_ = nil != b
+	_ = a.GetS() == b.GetS()
+	_ = reflect.DeepEqual(a.GetS(), b.GetS())
+	assert.Equal(tb, a.GetS(), b.GetS())
+	_ = cmp.Diff(a.GetS(), b.GetS())
+}
@@ -0,0 +1,86 @@
+package copycheck
+
+import (
+	"fmt"
+
+	"github.com/ghostiam/protogetter/testdata/proto"
+)
+
+// localMsg is a message declared next to the code, so it can have methods.
+type localMsg struct {
+	S string
+}
+
+func (*localMsg) ProtoMessage() {}
+
+func (m localMsg) Name() string { // want `avoid value receiver of proto message localMsg, use a pointer receiver instead`
+	return m.S
+}
+
+func (m *localMsg) Ptr() string {
+	return m.S
+}
+
+func testInvalid(t *proto.Test, other *proto.Embedded) {
+	e := *t.GetEmbedded() // want `avoid copying proto message \*t\.GetEmbedded\(\) by value, use a pointer instead`
+	_ = e.GetS()
+
+	var m proto.Embedded = *other // want `avoid copying proto message \*other by value, use a pointer instead`
+	_ = m.GetS()
+
+	var n = *other // want `avoid copying proto message \*other by value, use a pointer instead`
+	_ = n.GetS()
+
+	*t.GetEmbedded() = *other // want `avoid copying proto message \*other by value, use a pointer instead`
+
+	fmt.Println(*other) // want `avoid copying proto message \*other by value, use a pointer instead`
+
+	var list []proto.Test    // want `avoid storing proto messages by value in \[\]proto\.Test, use pointers instead`
+	for _, v := range list { // want `avoid copying proto messages by value when ranging over list, use pointers instead`
+		_ = v.GetS()
+	}
+
+	first := list[0] // want `avoid copying proto message list\[0\] by value, use a pointer instead`
+	_ = first.GetS()
+
+	var ptrs *[]proto.Test // want `avoid storing proto messages by value in \[\]proto\.Test, use pointers instead`
+	_ = (*ptrs)[42].GetS()
+
+	_ = map[string]proto.Test{} // want `avoid storing proto messages by value in map\[string\]proto\.Test, use pointers instead`
+
+	_ = []any{*other} // want `avoid copying proto message \*other by value, use a pointer instead`
+
+	// proto.Clone needs a v2 message, so the copy is not fixed.
+	local := &localMsg{}
+	copied := *local // want `avoid copying proto message \*local by value, use a pointer instead`
+	_ = copied.Ptr()
+}
+
+func byValue(m proto.Test) {} // want `avoid passing proto message proto\.Test by value, use a pointer instead`
+
+func returnsValue(m *proto.Test) proto.Test { // want `avoid returning proto message proto\.Test by value, use a pointer instead`
+	return *m // want `avoid copying proto message \*m by value, use a pointer instead`
+}
+
+func testValid(t *proto.Test, list []*proto.Test) {
+	e := t.GetEmbedded()
+	_ = e.GetS()
+
+	*t.GetEmbedded() = proto.Embedded{}
+	_ = (*t).GetS()
+	_ = (*t.GetEmbedded()).GetS()
+
+	for _, v := range list {
+		_ = v.GetS()
+	}
+
+	for i := range list {
+		_ = list[i].GetS()
+	}
+
+	m := &proto.Embedded{}
+	_ = m.GetS()
+
+	var local localMsg
+	_ = local.Ptr()
+}
@@ -0,0 +1,25 @@
+package deprecated
+
+import (
+	"github.com/ghostiam/protogetter/testdata/proto"
+)
+
+func testInvalid(l *proto.Legacy) {
+	_ = l.GetOldField()           // want `proto field Legacy\.old_field is deprecated, marked as deprecated in test_deprecated\.proto`
+	l.OldField = 1                // want `proto field Legacy\.old_field is deprecated, marked as deprecated in test_deprecated\.proto`
+	_ = l.GetOldChild().GetName() // want `proto field Legacy\.old_child is deprecated, marked as deprecated in test_deprecated\.proto`
+
+	_ = &proto.Legacy{
+		Name:     "name",
+		OldField: 2, // want `proto field Legacy\.old_field is deprecated, marked as deprecated in test_deprecated\.proto`
+	}
+
+	_ = proto.Level_LEVEL_LOW // want `proto enum value Level\.LEVEL_LOW is deprecated, marked as deprecated in test_deprecated\.proto`
+}
+
+func testValid(l *proto.Legacy) {
+	_ = l.GetName()
+	l.Name = "name"
+	_ = proto.Level_LEVEL_HIGH
+	_ = proto.Level_LEVEL_UNSPECIFIED
+}
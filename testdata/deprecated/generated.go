@@ -0,0 +1,16 @@
+package deprecated
+
+import (
+	"github.com/ghostiam/protogetter/testdata/deprecatedpb"
+)
+
+func testDescriptorOnly(a *deprecatedpb.Archived) {
+	_ = a.GetOldField() // want `proto field Archived\.old_field is deprecated, marked as deprecated in archived\.proto`
+	a.OldField = 1      // want `proto field Archived\.old_field is deprecated, marked as deprecated in archived\.proto`
+	_ = a.GetName()
+}
+
+func testMissingSource(t *deprecatedpb.Trimmed) { // want `generated source .*trimmed\.pb\.go of package github\.com/ghostiam/protogetter/testdata/deprecatedpb is not available, its deprecated fields and enum values are not checked`
+	_ = t.GetOldField()
+	_ = t.OldField
+}
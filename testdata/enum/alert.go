@@ -0,0 +1,9 @@
+package enum
+
+import (
+	enumv1 "github.com/ghostiam/protogetter/testdata/enumpb/v1"
+)
+
+func getAlert() *enumv1.Alert {
+	return &enumv1.Alert{}
+}
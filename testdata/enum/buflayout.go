@@ -0,0 +1,5 @@
+package enum
+
+func testBufLayoutImport() {
+	_ = getAlert().GetLevel() == 1 // want `avoid comparing proto enum getAlert\(\)\.GetLevel\(\) with literal 1, use enumv1\.Level_LEVEL_HIGH instead`
+}
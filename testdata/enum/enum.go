@@ -0,0 +1,62 @@
+package enum
+
+import (
+	"github.com/ghostiam/protogetter/testdata/proto"
+)
+
+func testInvalid(t *proto.Test, i int32) {
+	switch t.GetStatus() { // want `switch on proto enum t\.GetStatus\(\) is missing cases: proto\.Status_STATUS_DISABLED, proto\.Status_STATUS_UNSPECIFIED`
+	case proto.Status_STATUS_ACTIVE:
+	}
+
+	switch t.GetOptEnum() { // want `switch on proto enum t\.GetOptEnum\(\) is missing cases: proto\.Test_O_ENUM2`
+	case proto.Test_O_ENUM1:
+	}
+
+	_ = proto.Status(i)     // want `unchecked conversion of i to proto enum proto\.Status, check the value with proto\.Status_name first`
+	_ = proto.Test_OEnum(7) // want `conversion of unknown value 7 to proto enum proto\.Test_OEnum`
+
+	_ = t.GetOptEnum() == 0 // want `avoid comparing proto enum t\.GetOptEnum\(\) with literal 0, use proto\.Test_O_ENUM1 instead`
+	_ = t.GetStatus() != 0  // want `avoid comparing proto enum t\.GetStatus\(\) with literal 0, use proto\.Status_STATUS_UNSPECIFIED instead`
+	_ = 2 == t.GetStatus()  // want `avoid comparing proto enum t\.GetStatus\(\) with literal 2, use proto\.Status_STATUS_DISABLED instead`
+	_ = t.GetStatus() > 1   // want `avoid comparing proto enum t\.GetStatus\(\) with literal 1, use proto\.Status_STATUS_ACTIVE instead`
+	_ = t.GetStatus() == 42 // want `avoid comparing proto enum t\.GetStatus\(\) with literal 42, it is not a known value`
+}
+
+func testInvalidChecked(v, val int32, x int) {
+	if _, ok := proto.Status_name[val]; ok {
+		_ = proto.Status(v) // want `unchecked conversion of v to proto enum proto\.Status, check the value with proto\.Status_name first`
+	}
+
+	if _, ok := proto.Status_name[int32(v)]; ok {
+		_ = proto.Status(int32(x)) // want `unchecked conversion of int32\(x\) to proto enum proto\.Status, check the value with proto\.Status_name first`
+	}
+}
+
+func getTest() *proto.Test {
+	return &proto.Test{}
+}
+
+func testValid(t *proto.Test, i int32) {
+	switch t.GetStatus() {
+	case proto.Status_STATUS_UNSPECIFIED, proto.Status_STATUS_ACTIVE, proto.Status_STATUS_DISABLED:
+	}
+
+	switch t.GetStatus() {
+	case proto.Status_STATUS_ACTIVE:
+	default:
+	}
+
+	if _, ok := proto.Status_name[i]; ok {
+		_ = proto.Status(i)
+	}
+
+	if _, ok := proto.Status_name[int32(i)]; ok {
+		_ = proto.Status(int32(i))
+	}
+
+	_ = proto.Status(1)
+	_ = proto.Status(proto.Test_O_ENUM2)
+	_ = t.GetStatus() == proto.Status_STATUS_UNSPECIFIED
+	_ = t.GetI32() == 0
+}
@@ -0,0 +1,5 @@
+package enum
+
+func testNoImport() {
+	_ = getTest().GetStatus() == 1 // want `avoid comparing proto enum getTest\(\)\.GetStatus\(\) with literal 1, use proto\.Status_STATUS_ACTIVE instead`
+}
@@ -0,0 +1,48 @@
+package fieldmask
+
+import (
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	"github.com/ghostiam/protogetter/testdata/proto"
+)
+
+const embeddedCounter = "embedded.counter"
+
+func testNew() (*fieldmaskpb.FieldMask, error) {
+	return fieldmaskpb.New(&proto.Test{},
+		"s",
+		"embeded.s",         // want `unknown field "embeded" in message Test of field mask path "embeded\.s", did you mean "embedded"\?`
+		"repeatedEmbeddeds", // want `unknown field "repeatedEmbeddeds" in message Test of field mask path "repeatedEmbeddeds", did you mean "repeated_embeddeds"\?`
+		"embedded.embedded.opt_bool",
+		embeddedCounter,
+		"embedded.unknown",     // want `unknown field "unknown" in message Embedded of field mask path "embedded\.unknown"`
+		"s.length",             // want `field "s" of message Test in field mask path "s\.length" is not a message`
+		"repeated_embeddeds.s", // want `repeated field "repeated_embeddeds" of message Test in field mask path "repeated_embeddeds\.s" must be the last segment`
+	)
+}
+
+func testAppend(mask *fieldmaskpb.FieldMask, paths []string) error {
+	if err := mask.Append(&proto.Embedded{}, []string{"counter", "countr"}...); err != nil { // want `unknown field "countr" in message Embedded of field mask path "countr", did you mean "counter"\?`
+		return err
+	}
+
+	return mask.Append(&proto.Embedded{}, paths...)
+}
+
+func testIsValid() bool {
+	mask := &fieldmaskpb.FieldMask{Paths: []string{"opt_bool", "optBool", "status"}} // want `unknown field "optBool" in message Test of field mask path "optBool", did you mean "opt_bool"\?`
+	return mask.IsValid(&proto.Test{})
+}
+
+func testRequest(t *proto.Test) *proto.UpdateTestRequest {
+	return &proto.UpdateTestRequest{
+		Test: t,
+		UpdateMask: &fieldmaskpb.FieldMask{
+			Paths: []string{"i32", "i23", "map"}, // want `unknown field "i23" in message Test of field mask path "i23", did you mean "i32"\?`
+		},
+	}
+}
+
+func testUnknownMessage() *fieldmaskpb.FieldMask {
+	return &fieldmaskpb.FieldMask{Paths: []string{"whatever"}}
+}
@@ -0,0 +1,35 @@
+package getterwrite
+
+import (
+	"github.com/ghostiam/protogetter/testdata/proto"
+)
+
+func testInvalid(t *proto.Test) {
+	t.GetEmbedded().S = "x"                             // want `avoid writing to t\.GetEmbedded\(\)\.S, t\.GetEmbedded\(\) returns nil when the field is not set`
+	t.GetEmbedded().Counter++                           // want `avoid writing to t\.GetEmbedded\(\)\.Counter, t\.GetEmbedded\(\) returns nil when the field is not set`
+	t.GetEmbedded().Counter += 2                        // want `avoid writing to t\.GetEmbedded\(\)\.Counter, t\.GetEmbedded\(\) returns nil when the field is not set`
+	t.GetEmbedded().GetEmbedded().S = "x"               // want `avoid writing to t\.GetEmbedded\(\)\.GetEmbedded\(\)\.S, t\.GetEmbedded\(\)\.GetEmbedded\(\) returns nil when the field is not set`
+	t.GetEmbedded().Embedded.S = "x"                    // want `avoid writing to t\.GetEmbedded\(\)\.Embedded\.S, t\.GetEmbedded\(\) returns nil when the field is not set`
+	*t.GetEmbedded().OptBool = true                     // want `avoid writing to \*t\.GetEmbedded\(\)\.OptBool, t\.GetEmbedded\(\) returns nil when the field is not set`
+	t.GetEmbedded().SetS("x")                           // want `avoid writing to t\.GetEmbedded\(\)\.SetS\("x"\), t\.GetEmbedded\(\) returns nil when the field is not set`
+	t.GetEmbedded().SetMap(map[string]string{"k": "v"}) // want `avoid writing to t\.GetEmbedded\(\)\.SetMap\(map\[string\]string{"k": "v"}\), t\.GetEmbedded\(\) returns nil when the field is not set`
+	t.GetEmbedded().Reset()                             // want `avoid writing to t\.GetEmbedded\(\)\.Reset\(\), t\.GetEmbedded\(\) returns nil when the field is not set`
+	(t.GetEmbedded()).S = "x"                           // want `avoid writing to \(t\.GetEmbedded\(\)\)\.S, t\.GetEmbedded\(\) returns nil when the field is not set`
+
+	// The receiver is not rewritten to a getter by the read check.
+	t.Embedded.SetS("x")
+}
+
+func testValid(t *proto.Test) {
+	t.Embedded.S = "x"
+	t.GetRepeatedEmbeddeds()[0].S = "x"
+	_ = t.GetEmbedded().GetS()
+
+	e := t.GetEmbedded()
+	if e != nil {
+		e.S = "x"
+	}
+
+	var tt struct{ S string }
+	tt.S = "x"
+}
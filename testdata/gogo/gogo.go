@@ -0,0 +1,35 @@
+package gogo
+
+import (
+	"github.com/ghostiam/protogetter/testdata/gogopb"
+	"github.com/ghostiam/protogetter/testdata/proto"
+)
+
+func testInvalid(e *gogopb.Event) {
+	_ = e.Owner.Name // want `avoid direct access to gogo proto field e\.Owner\.Name, e\.Owner may be nil, use e\.Owner\.GetName\(\) instead`
+
+	_ = e.Owner.Address.City // want `avoid direct access to gogo proto field e\.Owner\.Address\.City, e\.Owner may be nil, use e\.Owner\.GetAddress\(\)\.GetCity\(\) instead`
+
+	_ = e.Meta.Root.Name // want `avoid direct access to gogo proto field e\.Meta\.Root\.Name, e\.Meta\.Root may be nil, use e\.Meta\.Root\.GetName\(\) instead`
+
+	println(e.Owner.Address) // want `avoid direct access to gogo proto field e\.Owner\.Address, e\.Owner may be nil, use e\.Owner\.GetAddress\(\) instead`
+}
+
+func testValid(e *gogopb.Event, t *proto.Test) {
+	_ = e.Name
+	_ = e.Owner
+	_ = e.Meta.Key
+	_ = e.Created.Unix()
+	_ = e.Owner.Id
+	_ = e.Owner.Nickname
+	_ = e.Owner.Age
+	_ = *e.Owner.Age
+	_ = e.Owner.GetName()
+	_ = e.GetOwner().GetAddress().GetCity()
+
+	e.Owner.Name = "name"
+	e.Owner.Address.City = "city"
+	_ = &e.Owner.Name
+
+	_ = t.GetEmbedded().GetS()
+}
@@ -0,0 +1,17 @@
+package gogomigrate
+
+import (
+	"github.com/ghostiam/protogetter/testdata/gogopb"
+)
+
+func testEqual(a, b *gogopb.Event) bool {
+	if a.Equal(b) { // want `gogo\.Event\.Equal: Equal goes away without gogo, use proto\.Equal instead, 2 uses break`
+		return true
+	}
+
+	return !a.Equal(&gogopb.Event{Name: "name"})
+}
+
+func testVerboseEqual(a, b *gogopb.Event) error {
+	return a.VerboseEqual(b) // want `gogo\.Event\.VerboseEqual: VerboseEqual goes away without gogo, use cmp\.Diff with protocmp\.Transform\(\) instead, 1 use breaks`
+}
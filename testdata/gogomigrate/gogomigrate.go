@@ -0,0 +1,52 @@
+package gogomigrate
+
+import (
+	"time"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/ghostiam/protogetter/testdata/gogopb"
+)
+
+func testStdtime(e *gogopb.Event, now time.Time) {
+	_ = e.Created.Unix() // want `gogo\.Event\.created: stdtime field becomes \*timestamppb\.Timestamp without gogo, 4 uses break`
+	e.Created = now
+	println(e.Created.IsZero())
+	_ = gogopb.Event{Created: now.Add(time.Hour)}
+}
+
+func testStdtimePointer(e *gogopb.Event) {
+	if e.Updated != nil { // want `gogo\.Event\.updated: stdtime field becomes \*timestamppb\.Timestamp without gogo, 2 uses break`
+		_ = e.Updated.Unix()
+	}
+}
+
+func testStdduration(e *gogopb.Event, d time.Duration) *durationpb.Duration {
+	e.Timeout = d // want `gogo\.Event\.timeout: stdduration field becomes \*durationpb\.Duration without gogo, 2 uses break`
+	return durationpb.New(e.Timeout)
+}
+
+func testNonNullable(e *gogopb.Event) {
+	meta := &e.Meta // want `gogo\.Event\.meta: nullable=false field becomes \*Meta without gogo, 3 uses break`
+	_ = meta
+	_ = gogopb.Event{Meta: gogopb.Meta{Key: "key"}}
+	var m gogopb.Meta = e.Meta
+	_ = m
+}
+
+func testCustomtype(e *gogopb.Event, u *gogopb.User) {
+	_ = e.Id // want `gogo\.Event\.id: customtype field becomes the proto scalar type without gogo, 1 use breaks`
+	_ = u.Id // want `gogo\.User\.id: customtype field becomes the proto scalar type without gogo, 1 use breaks`
+}
+
+func testCasttype(e *gogopb.Event) {
+	e.Priority = gogopb.Priority(1) // want `gogo\.Event\.priority: casttype field becomes int32 without gogo, 2 uses break`
+	_ = gogopb.Event{Priority: 2}
+}
+
+func testValid(e *gogopb.Event) {
+	_ = e.Name
+	_ = e.Owner.Name
+	_ = e.Meta.Key
+	_ = e.GetName()
+}
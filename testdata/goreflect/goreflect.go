@@ -0,0 +1,67 @@
+package goreflect
+
+import (
+	"reflect"
+
+	"github.com/go-viper/mapstructure/v2"
+	"github.com/jinzhu/copier"
+	protov2 "google.golang.org/protobuf/proto"
+
+	"github.com/ghostiam/protogetter/testdata/proto"
+)
+
+type plain struct {
+	Foo string
+}
+
+func testReflectFields(t *proto.Test, p *plain) {
+	_ = reflect.ValueOf(t).Elem().FieldByName("S") // want `avoid reflect\.Value\.FieldByName on proto message t, Go reflection sees the internal state, sizeCache and unknownFields fields and ignores oneofs, use t\.ProtoReflect\(\) instead`
+
+	v := reflect.Indirect(reflect.ValueOf(t))
+	for i := 0; i < v.NumField(); i++ { // want `avoid reflect\.Value\.NumField on proto message t`
+		_ = v.Field(i)
+	}
+
+	typ := reflect.TypeOf(t).Elem()
+	for i := range typ.NumField() { // want `avoid reflect\.Type\.NumField on proto message t`
+		_ = typ.Field(i).Name
+	}
+
+	for _, f := range reflect.VisibleFields(reflect.TypeOf(*t)) { // want `avoid reflect\.VisibleFields on proto message \*t`
+		_ = f.Name
+	}
+
+	_ = reflect.ValueOf(p).Elem().FieldByName("Foo")
+	_ = reflect.ValueOf(t).MethodByName("GetS")
+	_ = reflect.TypeOf(t).String()
+}
+
+func testCopiers(t *proto.Test, p *plain, m map[string]any) error {
+	if err := copier.Copy(p, t); err != nil { // want `avoid copier\.Copy with proto message t, it copies the internal fields as a Go struct and ignores oneofs, use proto\.Clone, proto\.Merge or ProtoReflect\(\) instead`
+		return err
+	}
+
+	var dst *proto.Test
+	if err := copier.CopyWithOption(&dst, t, copier.Option{DeepCopy: true}); err != nil { // want `avoid copier\.CopyWithOption with proto message &dst`
+		return err
+	}
+
+	if err := mapstructure.Decode(m, t); err != nil { // want `avoid mapstructure\.Decode with proto message t`
+		return err
+	}
+
+	dec, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{Result: p})
+	if err != nil {
+		return err
+	}
+	if err := dec.Decode(t); err != nil { // want `avoid mapstructure\.Decoder\.Decode with proto message t`
+		return err
+	}
+
+	if err := copier.Copy(p, &plain{}); err != nil {
+		return err
+	}
+
+	protov2.Merge(dst, t)
+	return mapstructure.Decode(m, p)
+}
@@ -0,0 +1,45 @@
+package hybrid
+
+import (
+	protov2 "google.golang.org/protobuf/proto"
+
+	"github.com/ghostiam/protogetter/testdata/proto"
+)
+
+func testInvalid(h *proto.TestHybrid, other *proto.TestHybrid, name *string) {
+	if h.Name != nil { // want `avoid comparing hybrid proto field h\.Name with nil, use h\.HasName\(\) instead`
+		_ = h.GetName()
+	}
+
+	if nil == h.Inner { // want `avoid comparing hybrid proto field h\.Inner with nil, use !h\.HasInner\(\) instead`
+		return
+	}
+
+	_ = h.Count != nil && h.GetCount() > 0 // want `avoid comparing hybrid proto field h\.Count with nil, use h\.HasCount\(\) instead`
+
+	h.Name = nil  // want `avoid clearing hybrid proto field h\.Name with nil, use h\.ClearName\(\) instead`
+	h.Inner = nil // want `avoid clearing hybrid proto field h\.Inner with nil, use h\.ClearInner\(\) instead`
+
+	h.Name = protov2.String("name")           // want `avoid direct write to hybrid proto field h\.Name, use h\.SetName\("name"\) instead`
+	h.Count = protov2.Int32(other.GetCount()) // want `avoid direct write to hybrid proto field h\.Count, use h\.SetCount\(other\.GetCount\(\)\) instead`
+	h.Inner = other.GetInner()                // want `avoid direct write to hybrid proto field h\.Inner, use h\.SetInner\(other\.GetInner\(\)\) instead`
+	h.Tags = nil                              // want `avoid direct write to hybrid proto field h\.Tags, use h\.SetTags\(nil\) instead`
+	h.Implicit = "implicit"                   // want `avoid direct write to hybrid proto field h\.Implicit, use h\.SetImplicit\("implicit"\) instead`
+	h.Name = name                             // want `avoid direct write to hybrid proto field h\.Name, use h\.SetName\(\) instead`
+
+	h.Implicit, h.Tags = "a", nil // want `avoid direct write to hybrid proto field h\.Implicit, use h\.SetImplicit\(\) instead` `avoid direct write to hybrid proto field h\.Tags, use h\.SetTags\(\) instead`
+}
+
+func testValid(h *proto.TestHybrid, t *proto.Test, e *proto.TestEdition2023) {
+	if h.HasName() {
+		h.SetName(h.GetName())
+	}
+
+	h.ClearInner()
+
+	_ = h.GetTags() != nil
+	_ = t.GetEmbedded() != nil
+	t.Embedded = nil
+	t.S = "s"
+	e.SetText("text")
+}
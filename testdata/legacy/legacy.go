@@ -0,0 +1,56 @@
+package legacy
+
+import (
+	"time"
+
+	"github.com/golang/protobuf/descriptor"
+	"github.com/golang/protobuf/jsonpb"
+	protov1 "github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/golang/protobuf/ptypes/timestamp"
+
+	"github.com/ghostiam/protogetter/testdata/proto"
+)
+
+func testInvalid(t *proto.Test, a *any.Any, ts *timestamp.Timestamp) { // want `any\.Any is deprecated, use anypb\.Any instead` `timestamp\.Timestamp is deprecated, use timestamppb\.Timestamp instead`
+	_, _ = protov1.Marshal(t)        // want `protov1\.Marshal is deprecated, use proto\.Marshal instead`
+	_ = protov1.Equal(t, t)          // want `protov1\.Equal is deprecated, use proto\.Equal instead`
+	_ = protov1.String("s")          // want `protov1\.String is deprecated, use proto\.String instead`
+	_ = protov1.MarshalTextString(t) // want `protov1\.MarshalTextString is deprecated, use prototext\.Format instead`
+
+	_ = ptypes.TimestampNow()                      // want `ptypes\.TimestampNow is deprecated, use timestamppb\.Now instead`
+	_ = ptypes.DurationProto(time.Second)          // want `ptypes\.DurationProto is deprecated, use durationpb\.New instead`
+	pbTime, _ := ptypes.TimestampProto(time.Now()) // want `ptypes\.TimestampProto is deprecated, use timestamppb\.New instead`
+	_ = pbTime
+	goTime, _ := ptypes.Timestamp(ts) // want `ptypes\.Timestamp is deprecated, use \(\*timestamppb\.Timestamp\)\.AsTime instead`
+	_ = goTime
+
+	// The error is used, so there is no fix.
+	_, err := ptypes.TimestampProto(time.Now()) // want `ptypes\.TimestampProto is deprecated, use timestamppb\.New instead`
+	_ = err
+
+	_, _ = ptypes.MarshalAny(t)   // want `ptypes\.MarshalAny is deprecated, use anypb\.New instead`
+	_ = ptypes.UnmarshalAny(a, t) // want `ptypes\.UnmarshalAny is deprecated, use \(\*anypb\.Any\)\.UnmarshalTo instead`
+	_ = ptypes.Is(a, t)           // want `ptypes\.Is is deprecated, use \(\*anypb\.Any\)\.MessageIs instead`
+
+	m := jsonpb.Marshaler{OrigName: true, EmitDefaults: true} // want `jsonpb\.Marshaler is deprecated, use protojson\.MarshalOptions instead, with UseProtoNames for OrigName, EmitUnpopulated for EmitDefaults$`
+	_ = m
+	u := &jsonpb.Unmarshaler{AllowUnknownFields: true} // want `jsonpb\.Unmarshaler is deprecated, use protojson\.UnmarshalOptions instead, with DiscardUnknown for AllowUnknownFields$`
+	_ = u.Unmarshal(nil, t)
+	_ = jsonpb.UnmarshalString("{}", t) // want `jsonpb\.UnmarshalString is deprecated, use protojson\.Unmarshal instead`
+
+	_, md := descriptor.ForMessage(t) // want `descriptor\.ForMessage is deprecated, use protodesc\.ToDescriptorProto instead`
+	_ = md
+}
+
+func testNoFix(m protov1.Message) protov1.Message { // want `protov1\.Message is deprecated, use proto\.Message instead` `protov1\.Message is deprecated, use proto\.Message instead`
+	// m is a v1 message, the v2 proto.Marshal does not accept it.
+	_, _ = protov1.Marshal(m) // want `protov1\.Marshal is deprecated, use proto\.Marshal instead`
+	return m
+}
+
+func testValid(t *proto.Test) {
+	_ = t.GetS()
+	_ = time.Now()
+}
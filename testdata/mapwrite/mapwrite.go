@@ -0,0 +1,49 @@
+package mapwrite
+
+import (
+	"github.com/ghostiam/protogetter/testdata/proto"
+)
+
+func testInvalid(t *proto.Test) {
+	t.Map["k"] = "v" // want `possible write to nil proto map t\.Map, allocate it before writing`
+}
+
+func testInvalidGetter(t *proto.Test) {
+	t.GetMap()["k"] = "v" // want `possible write to nil proto map t\.GetMap\(\), allocate it before writing`
+}
+
+func testInvalidNew() {
+	t := &proto.Test{S: "s"}
+	t.Map["k"] += "v" // want `possible write to nil proto map t\.Map, allocate it before writing`
+	t.Map["k2"] = "v" // Reported once per map.
+}
+
+func testInvalidNested(t *proto.Test, src map[string]string) {
+	for k, v := range src {
+		t.Map[k] = v // want `possible write to nil proto map t\.Map, allocate it before writing`
+	}
+}
+
+func testInvalidNoFix(ts []*proto.Test) {
+	if ts[0].Map["k"] = "v"; true { // want `possible write to nil proto map ts\[0\]\.Map, allocate it before writing`
+		return
+	}
+}
+
+func testValid(t *proto.Test) {
+	t.Map = make(map[string]string)
+	t.Map["k"] = "v"
+
+	t2 := &proto.Test{Map: map[string]string{}}
+	t2.Map["k"] = "v"
+	t2.GetMap()["k"] = "v"
+
+	t3 := &proto.Test{}
+	if t3.Map == nil {
+		t3.Map = map[string]string{}
+	}
+	t3.Map["k"] = "v"
+
+	m := map[string]string{}
+	m["k"] = "v"
+}
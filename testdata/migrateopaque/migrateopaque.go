@@ -0,0 +1,35 @@
+package migrateopaque
+
+import (
+	protov2 "google.golang.org/protobuf/proto"
+
+	"github.com/ghostiam/protogetter/testdata/proto"
+)
+
+func testMigrate(h *proto.TestHybrid, name *string) *proto.TestHybrid {
+	_ = &proto.TestHybrid{ // want `avoid composite literal of hybrid proto message proto\.TestHybrid, use proto\.TestHybrid_builder\{\.\.\.\}\.Build\(\) instead`
+		Name:  protov2.String("name"),
+		Inner: &proto.TestHybridInner{Value: name}, // want `avoid composite literal of hybrid proto message proto\.TestHybridInner, use proto\.TestHybridInner_builder\{\.\.\.\}\.Build\(\) instead`
+	}
+
+	_ = []*proto.TestHybrid{
+		{Implicit: "implicit"}, // want `avoid composite literal of hybrid proto message proto\.TestHybrid, use proto\.TestHybrid_builder\{\.\.\.\}\.Build\(\) instead`
+		{},
+	}
+
+	if h.Name != nil { // want `avoid comparing hybrid proto field h\.Name with nil, use h\.HasName\(\) instead`
+		h.Implicit = "implicit" // want `avoid direct write to hybrid proto field h\.Implicit, use h\.SetImplicit\("implicit"\) instead`
+	}
+
+	h.Count = nil                // want `avoid clearing hybrid proto field h\.Count with nil, use h\.ClearCount\(\) instead`
+	_ = h.Implicit               // want `avoid direct access to proto field h\.Implicit, use h\.GetImplicit\(\) instead`
+	h.Tags = append(h.Tags, "t") // want `avoid direct write to hybrid proto field h\.Tags, use h\.SetTags\(append\(h\.GetTags\(\), "t"\)\) instead`
+
+	p := &h.Implicit // want `cannot migrate direct access to h\.Implicit to the opaque API automatically, only the value of the field is accessible, with h\.GetImplicit\(\)`
+	_ = p
+
+	v := proto.TestHybridInner{Value: name} // want `cannot migrate literal of TestHybridInner to the opaque API automatically, the builder creates a pointer, not a message value`
+	_ = v.GetValue()
+
+	return &proto.TestHybrid{}
+}
@@ -0,0 +1,64 @@
+package nestedwrite
+
+import (
+	"github.com/ghostiam/protogetter/testdata/proto"
+)
+
+func testInvalid(t *proto.Test, many []*proto.Test) {
+	t.Embedded.S = "x"                  // want `avoid writing to proto field t\.Embedded\.S through possibly nil message t\.Embedded`
+	t.Embedded.Embedded.Counter++       // want `avoid writing to proto field t\.Embedded\.Embedded\.Counter through possibly nil message t\.Embedded`
+	many[0].Embedded.Counter += 2       // want `avoid writing to proto field many\[0\]\.Embedded\.Counter through possibly nil message many\[0\]\.Embedded`
+	t.Embedded.Embedded = nil           // want `avoid writing to proto field t\.Embedded\.Embedded through possibly nil message t\.Embedded`
+	_, t.Embedded.OptBool = 1, nil      // want `avoid writing to proto field t\.Embedded\.OptBool through possibly nil message t\.Embedded`
+	*t.Embedded.Embedded.OptBool = true // want `avoid writing to proto field t\.Embedded\.Embedded\.OptBool through possibly nil message t\.Embedded`
+
+	if t.GetS() == "" {
+		t.Embedded.S = "y" // want `avoid writing to proto field t\.Embedded\.S through possibly nil message t\.Embedded`
+	}
+
+	// The fix is not suggested when the parent can't be evaluated twice.
+	next().Embedded.S = "x" // want `avoid writing to proto field next\(\)\.Embedded\.S through possibly nil message next\(\)\.Embedded`
+
+	for i := 0; i < 1; t.Embedded.Counter++ { // want `avoid writing to proto field t\.Embedded\.Counter through possibly nil message t\.Embedded`
+		i++
+	}
+}
+
+func testPartiallyGuarded(t *proto.Test) {
+	if t.GetEmbedded() != nil {
+		t.Embedded.Embedded.S = "x" // want `avoid writing to proto field t\.Embedded\.Embedded\.S through possibly nil message t\.Embedded\.Embedded`
+	}
+}
+
+func testValid(t *proto.Test) {
+	t.S = "x"
+	t.Embedded = &proto.Embedded{}
+	t.Embedded.S = "x"
+	t.Embedded.Counter++
+
+	if t.GetEmbedded().GetEmbedded() != nil {
+		t.Embedded.Embedded.S = "x"
+	}
+
+	if t.GetEmbedded() != nil && t.GetEmbedded().GetEmbedded() != nil {
+		t.Embedded.Embedded.Counter++
+	}
+
+	var tt struct{ Embedded *proto.Embedded }
+	tt.Embedded.S = "x"
+
+	e := t.GetEmbedded()
+	e.S = "x"
+}
+
+func testEarlyReturn(t *proto.Test) {
+	if t.GetEmbedded() == nil {
+		return
+	}
+
+	t.Embedded.S = "x"
+}
+
+func next() *proto.Test {
+	return nil
+}
@@ -0,0 +1,52 @@
+package oneofswitch
+
+import (
+	pb "github.com/ghostiam/protogetter/testdata/proto"
+)
+
+func testInvalid(t *pb.Foo, e *pb.TestEdition2023) {
+	switch v := t.GetBar().(type) { // want `switch on oneof t\.GetBar\(\) is missing cases: \*pb\.Foo_Quix`
+	case *pb.Foo_Baz:
+		_ = v.Baz
+	}
+
+	switch t.GetBar().(type) { // want `switch on oneof t\.GetBar\(\) is missing cases: \*pb\.Foo_Baz, \*pb\.Foo_Quix`
+	case nil:
+	}
+
+	switch e.WhichPayload() { // want `switch on oneof e\.WhichPayload\(\) is missing cases: pb\.TestEdition2023_Number_case`
+	case pb.TestEdition2023_Text_case:
+	case pb.TestEdition2023_Payload_not_set_case:
+	}
+}
+
+func testValid(t *pb.Foo, e *pb.TestEdition2023, x interface{}) {
+	switch v := t.GetBar().(type) {
+	case *pb.Foo_Baz:
+		_ = v.Baz
+	case *pb.Foo_Quix:
+		_ = v.Quix
+	}
+
+	switch t.GetBar().(type) {
+	case *pb.Foo_Baz, *pb.Foo_Quix:
+	}
+
+	switch t.GetBar().(type) {
+	case *pb.Foo_Baz:
+	default:
+	}
+
+	switch e.WhichPayload() {
+	case pb.TestEdition2023_Text_case, pb.TestEdition2023_Number_case:
+	}
+
+	switch e.WhichPayload() {
+	case pb.TestEdition2023_Text_case:
+	default:
+	}
+
+	switch x.(type) {
+	case *pb.Foo_Baz:
+	}
+}
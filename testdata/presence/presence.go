@@ -0,0 +1,61 @@
+package presence
+
+import (
+	protov2 "google.golang.org/protobuf/proto"
+
+	"github.com/ghostiam/protogetter/testdata/proto"
+)
+
+func testInvalid(t *proto.Test, p *proto.TestProto2, h *proto.TestHybrid) {
+	if t.GetOptBool() == false { // want `comparing t\.GetOptBool\(\) with false can't tell an unset field from a field set to false$`
+		t.OptBool = protov2.Bool(true)
+	}
+
+	if t.GetOptBool() == false { // want `comparing t\.GetOptBool\(\) with false can't tell an unset field from a field set to false, use t\.OptBool == nil to test the presence`
+		println(t.GetOptBool())
+	}
+
+	if t.GetOptEnum() != 0 { // want `comparing t\.GetOptEnum\(\) with 0 can't tell an unset field from a field set to 0, use t\.OptEnum != nil to test the presence`
+		_ = t.GetOptEnum().String()
+	}
+
+	if ok := true; ok && proto.Test_O_ENUM1 != t.GetOptEnum() { // want `comparing t\.GetOptEnum\(\) with proto\.Test_O_ENUM1 can't tell an unset field from a field set to proto\.Test_O_ENUM1, use t\.OptEnum != nil to test the presence`
+		_ = t.GetOptEnum()
+	}
+
+	if p.GetS() == "" { // want `comparing p\.GetS\(\) with "" can't tell an unset field from a field set to "", use p\.S == nil to test the presence`
+		_ = p.GetS()
+	}
+
+	if h.GetCount() != 0 { // want `comparing h\.GetCount\(\) with 0 can't tell an unset field from a field set to 0$`
+		h.SetCount(h.GetCount() + 1)
+	}
+
+	if h.GetCount() != 0 { // want `comparing h\.GetCount\(\) with 0 can't tell an unset field from a field set to 0, use h\.HasCount\(\) to test the presence`
+		println(h.GetCount())
+	}
+
+	if h.GetInner().GetValue() == "" { // want `comparing h\.GetInner\(\)\.GetValue\(\) with "" can't tell an unset field from a field set to "", use !h\.GetInner\(\)\.HasValue\(\) to test the presence`
+		println(h.GetInner().GetValue())
+	}
+
+	if t.GetEmbedded().GetOptBool() == false { // want `comparing t\.GetEmbedded\(\)\.GetOptBool\(\) with false can't tell an unset field from a field set to false$`
+		println(t.GetEmbedded().GetOptBool())
+	}
+
+	_ = t.GetOptBool() == false // want `comparing t\.GetOptBool\(\) with false can't tell an unset field from a field set to false$`
+
+	if t.GetOptEnum() != 0 { // want `comparing t\.GetOptEnum\(\) with 0 can't tell an unset field from a field set to 0$`
+		_ = t.GetI32()
+	}
+}
+
+func testValid(t *proto.Test, p *proto.TestProto2, h *proto.TestHybrid) {
+	_ = t.GetI32() == 0
+	_ = t.GetS() == ""
+	_ = t.GetOptBool() == true
+	_ = t.GetOptEnum() == proto.Test_O_ENUM2
+	_ = h.GetImplicit() == ""
+	_ = t.OptBool != nil
+	_ = h.HasName()
+}
@@ -22,10 +22,11 @@ const (
 )
 
 type TestEdition2023 struct {
-	state            protoimpl.MessageState `protogen:"opaque.v1"`
-	xxx_hidden_Value map[string]string      `protobuf:"bytes,1,rep,name=value" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
+	state              protoimpl.MessageState    `protogen:"opaque.v1"`
+	xxx_hidden_Value   map[string]string         `protobuf:"bytes,1,rep,name=value" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	xxx_hidden_Payload isTestEdition2023_Payload `protobuf_oneof:"payload"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
 }
 
 func (x *TestEdition2023) Reset() {
@@ -60,14 +61,101 @@ func (x *TestEdition2023) GetValue() map[string]string {
 	return nil
 }
 
+func (x *TestEdition2023) GetText() string {
+	if x != nil {
+		if x, ok := x.xxx_hidden_Payload.(*testEdition2023_Text); ok {
+			return x.Text
+		}
+	}
+	return ""
+}
+
+func (x *TestEdition2023) GetNumber() int64 {
+	if x != nil {
+		if x, ok := x.xxx_hidden_Payload.(*testEdition2023_Number); ok {
+			return x.Number
+		}
+	}
+	return 0
+}
+
 func (x *TestEdition2023) SetValue(v map[string]string) {
 	x.xxx_hidden_Value = v
 }
 
+func (x *TestEdition2023) SetText(v string) {
+	x.xxx_hidden_Payload = &testEdition2023_Text{v}
+}
+
+func (x *TestEdition2023) SetNumber(v int64) {
+	x.xxx_hidden_Payload = &testEdition2023_Number{v}
+}
+
+func (x *TestEdition2023) HasPayload() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Payload != nil
+}
+
+func (x *TestEdition2023) HasText() bool {
+	if x == nil {
+		return false
+	}
+	_, ok := x.xxx_hidden_Payload.(*testEdition2023_Text)
+	return ok
+}
+
+func (x *TestEdition2023) HasNumber() bool {
+	if x == nil {
+		return false
+	}
+	_, ok := x.xxx_hidden_Payload.(*testEdition2023_Number)
+	return ok
+}
+
+func (x *TestEdition2023) ClearPayload() {
+	x.xxx_hidden_Payload = nil
+}
+
+func (x *TestEdition2023) ClearText() {
+	if _, ok := x.xxx_hidden_Payload.(*testEdition2023_Text); ok {
+		x.xxx_hidden_Payload = nil
+	}
+}
+
+func (x *TestEdition2023) ClearNumber() {
+	if _, ok := x.xxx_hidden_Payload.(*testEdition2023_Number); ok {
+		x.xxx_hidden_Payload = nil
+	}
+}
+
+const TestEdition2023_Payload_not_set_case case_TestEdition2023_Payload = 0
+const TestEdition2023_Text_case case_TestEdition2023_Payload = 2
+const TestEdition2023_Number_case case_TestEdition2023_Payload = 3
+
+func (x *TestEdition2023) WhichPayload() case_TestEdition2023_Payload {
+	if x == nil {
+		return TestEdition2023_Payload_not_set_case
+	}
+	switch x.xxx_hidden_Payload.(type) {
+	case *testEdition2023_Text:
+		return TestEdition2023_Text_case
+	case *testEdition2023_Number:
+		return TestEdition2023_Number_case
+	default:
+		return TestEdition2023_Payload_not_set_case
+	}
+}
+
 type TestEdition2023_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
 	Value map[string]string
+	// Fields of oneof xxx_hidden_Payload:
+	Text   *string
+	Number *int64
+	// -- end of xxx_hidden_Payload
 }
 
 func (b0 TestEdition2023_builder) Build() *TestEdition2023 {
@@ -75,29 +163,64 @@ func (b0 TestEdition2023_builder) Build() *TestEdition2023 {
 	b, x := &b0, m0
 	_, _ = b, x
 	x.xxx_hidden_Value = b.Value
+	if b.Text != nil {
+		x.xxx_hidden_Payload = &testEdition2023_Text{*b.Text}
+	}
+	if b.Number != nil {
+		x.xxx_hidden_Payload = &testEdition2023_Number{*b.Number}
+	}
 	return m0
 }
 
+type case_TestEdition2023_Payload protoreflect.FieldNumber
+
+func (x case_TestEdition2023_Payload) String() string {
+	md := file_test_edition_2023_proto_msgTypes[0].Descriptor()
+	if x == 0 {
+		return "not set"
+	}
+	return protoimpl.X.MessageFieldStringOf(md, protoreflect.FieldNumber(x))
+}
+
+type isTestEdition2023_Payload interface {
+	isTestEdition2023_Payload()
+}
+
+type testEdition2023_Text struct {
+	Text string `protobuf:"bytes,2,opt,name=text,oneof"`
+}
+
+type testEdition2023_Number struct {
+	Number int64 `protobuf:"varint,3,opt,name=number,oneof"`
+}
+
+func (*testEdition2023_Text) isTestEdition2023_Payload() {}
+
+func (*testEdition2023_Number) isTestEdition2023_Payload() {}
+
 var File_test_edition_2023_proto protoreflect.FileDescriptor
 
 var file_test_edition_2023_proto_rawDesc = string([]byte{
 	0x0a, 0x17, 0x74, 0x65, 0x73, 0x74, 0x5f, 0x65, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x32,
 	0x30, 0x32, 0x33, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x21, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
 	0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x67, 0x6f, 0x5f, 0x66, 0x65,
-	0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x7e, 0x0a, 0x0f,
-	0x54, 0x65, 0x73, 0x74, 0x45, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x32, 0x30, 0x32, 0x33, 0x12,
-	0x31, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b,
-	0x2e, 0x54, 0x65, 0x73, 0x74, 0x45, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x32, 0x30, 0x32, 0x33,
-	0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x05, 0x76, 0x61, 0x6c,
-	0x75, 0x65, 0x1a, 0x38, 0x0a, 0x0a, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x79,
-	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
-	0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x42, 0x38, 0x5a, 0x2e,
-	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x67, 0x68, 0x6f, 0x73, 0x74,
-	0x69, 0x61, 0x6d, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x67, 0x65, 0x74, 0x74, 0x65, 0x72, 0x2f,
-	0x74, 0x65, 0x73, 0x74, 0x64, 0x61, 0x74, 0x61, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x92, 0x03,
-	0x05, 0xd2, 0x3e, 0x02, 0x10, 0x03, 0x62, 0x08, 0x65, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73,
-	0x70, 0xe8, 0x07,
+	0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xb9, 0x01, 0x0a,
+	0x0f, 0x54, 0x65, 0x73, 0x74, 0x45, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x32, 0x30, 0x32, 0x33,
+	0x12, 0x31, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x1b, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x45, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x32, 0x30, 0x32,
+	0x33, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x12, 0x14, 0x0a, 0x04, 0x74, 0x65, 0x78, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x48, 0x00, 0x52, 0x04, 0x74, 0x65, 0x78, 0x74, 0x12, 0x18, 0x0a, 0x06, 0x6e, 0x75, 0x6d,
+	0x62, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x48, 0x00, 0x52, 0x06, 0x6e, 0x75, 0x6d,
+	0x62, 0x65, 0x72, 0x1a, 0x38, 0x0a, 0x0a, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x42, 0x09, 0x0a,
+	0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x42, 0x38, 0x5a, 0x2e, 0x67, 0x69, 0x74, 0x68,
+	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x67, 0x68, 0x6f, 0x73, 0x74, 0x69, 0x61, 0x6d, 0x2f,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x67, 0x65, 0x74, 0x74, 0x65, 0x72, 0x2f, 0x74, 0x65, 0x73, 0x74,
+	0x64, 0x61, 0x74, 0x61, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x92, 0x03, 0x05, 0xd2, 0x3e, 0x02,
+	0x10, 0x03, 0x62, 0x08, 0x65, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x70, 0xe8, 0x07,
 })
 
 var file_test_edition_2023_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
@@ -119,6 +242,10 @@ func file_test_edition_2023_proto_init() {
 	if File_test_edition_2023_proto != nil {
 		return
 	}
+	file_test_edition_2023_proto_msgTypes[0].OneofWrappers = []any{
+		(*testEdition2023_Text)(nil),
+		(*testEdition2023_Number)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
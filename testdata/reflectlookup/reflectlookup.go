@@ -0,0 +1,62 @@
+package reflectlookup
+
+import (
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+
+	"github.com/ghostiam/protogetter/testdata/proto"
+)
+
+func testFields(t *proto.Test) {
+	_ = t.ProtoReflect().Descriptor().Fields().ByName("bogus")   // want `Fields\(\)\.ByName never succeeds, message Test has no field "bogus"`
+	_ = t.ProtoReflect().Descriptor().Fields().ByName("embeded") // want `Fields\(\)\.ByName never succeeds, message Test has no field "embeded", did you mean "embedded"\?`
+	_ = t.ProtoReflect().Descriptor().Fields().ByName("embedded")
+	_ = t.ProtoReflect().Descriptor().Fields().ByNumber(99) // want `Fields\(\)\.ByNumber never succeeds, message Test has no field number 99`
+	_ = t.ProtoReflect().Descriptor().Fields().ByNumber(15)
+	_ = t.ProtoReflect().Descriptor().Fields().ByJSONName("optBool")
+	_ = t.ProtoReflect().Descriptor().Fields().ByJSONName("opt_bool") // want `Fields\(\)\.ByJSONName never succeeds, message Test has no field with JSON name "opt_bool", did you mean "optBool"\?`
+}
+
+func testVars(e *proto.Embedded) protoreflect.Value {
+	m := e.ProtoReflect()
+	md := m.Descriptor()
+
+	var fields = md.Fields()
+	_ = fields.ByName("s") // Not resolved through the list variable.
+
+	_ = m.Type().Descriptor().Fields().ByName("countr") // want `Fields\(\)\.ByName never succeeds, message Embedded has no field "countr", did you mean "counter"\?`
+	return m.Get(md.Fields().ByName("counter"))
+}
+
+func testOneofs() {
+	md := (*proto.Foo)(nil).ProtoReflect().Descriptor()
+	_ = md.Oneofs().ByName("bar")
+	_ = md.Oneofs().ByName("baz") // want `Oneofs\(\)\.ByName never succeeds, message Foo has no oneof "baz", did you mean "bar"\?`
+}
+
+func testRegistry(name protoreflect.FullName) {
+	_, _ = protoregistry.GlobalTypes.FindMessageByName("Test")
+	_, _ = protoregistry.GlobalTypes.FindMessageByName("Tset")                       // want `FindMessageByName may not find "Tset", no message of proto package "" imported here has this name, did you mean "Test"\?`
+	_, _ = protoregistry.GlobalTypes.FindMessageByURL("type.googleapis.com/Embeded") // want `FindMessageByURL may not find "Embeded", no message of proto package "" imported here has this name, did you mean "Embedded"\?`
+	_, _ = protoregistry.GlobalTypes.FindMessageByName("foo.v1.Tset")
+	_, _ = protoregistry.GlobalTypes.FindMessageByName("RegisteredElsewhere")
+	_, _ = protoregistry.GlobalTypes.FindMessageByName(name)
+}
+
+func otherMessage() protoreflect.Message {
+	return nil
+}
+
+func testReassigned(t *proto.Test) {
+	m := t.ProtoReflect()
+	m = otherMessage()
+	_ = m.Descriptor().Fields().ByName("x")
+
+	md := t.ProtoReflect().Descriptor()
+	md, _ = otherDescriptor()
+	_ = md.Fields().ByName("x")
+}
+
+func otherDescriptor() (protoreflect.MessageDescriptor, error) {
+	return nil, nil
+}
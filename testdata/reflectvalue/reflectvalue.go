@@ -0,0 +1,53 @@
+package reflectvalue
+
+import (
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/ghostiam/protogetter/testdata/proto"
+)
+
+func testSet(t *proto.Test, e *proto.Embedded) {
+	m := t.ProtoReflect()
+	fields := m.Descriptor().Fields()
+	_ = fields
+
+	i32 := m.Descriptor().Fields().ByName("i32")
+	m.Set(i32, protoreflect.ValueOfString("1")) // want `protoreflect\.ValueOfString does not match field Test\.i32 of kind int32`
+	m.Set(i32, protoreflect.ValueOfInt32(1))
+	m.Set(i32, protoreflect.ValueOfInt64(1)) // want `protoreflect\.ValueOfInt64 does not match field Test\.i32 of kind int32`
+
+	m.Set(m.Descriptor().Fields().ByNumber(9), protoreflect.ValueOfString("s"))
+	m.Set(m.Descriptor().Fields().ByName("status"), protoreflect.ValueOfEnum(1))
+	m.Set(m.Descriptor().Fields().ByName("b"), protoreflect.ValueOfString("b")) // want `protoreflect\.ValueOfString does not match field Test\.b of kind bytes`
+
+	repeated := m.Descriptor().Fields().ByName("repeated_embeddeds")
+	m.Set(repeated, protoreflect.ValueOfMessage(e.ProtoReflect())) // want `protoreflect\.ValueOfMessage does not match repeated field Test\.repeated_embeddeds, use protoreflect\.ValueOfList`
+	m.Set(repeated, protoreflect.ValueOfList(m.NewField(repeated).List()))
+	m.Set(m.Descriptor().Fields().ByName("map"), protoreflect.ValueOfList(nil)) // want `protoreflect\.ValueOfList does not match map field Test\.map, use protoreflect\.ValueOfMap`
+	m.Set(m.Descriptor().Fields().ByName("s"), protoreflect.ValueOfMap(nil))    // want `protoreflect\.ValueOfMap does not match field Test\.s of kind string`
+
+	embedded := m.Descriptor().Fields().ByName("embedded")
+	m.Set(embedded, protoreflect.ValueOfMessage(e.ProtoReflect()))
+	m.Set(embedded, protoreflect.ValueOfMessage(t.ProtoReflect())) // want `protoreflect\.ValueOfMessage of message \*proto\.Test does not match field Test\.embedded of message \*proto\.Embedded`
+}
+
+func testGet(t *proto.Test) {
+	md := t.ProtoReflect().Descriptor()
+	m := t.ProtoReflect()
+
+	_ = m.Get(md.Fields().ByName("s")).String()
+	_ = m.Get(md.Fields().ByName("s")).Int() // want `Value\.Int does not match field Test\.s of kind string`
+	_ = m.Get(md.Fields().ByName("u64")).Uint()
+	_ = m.Get(md.Fields().ByName("f")).Float()
+	_ = m.Get(md.Fields().ByName("embedded")).Message()
+	_ = m.Get(md.Fields().ByName("repeated_embeddeds")).Message() // want `Value\.Message does not match repeated field Test\.repeated_embeddeds, use Value\.List`
+	_ = m.Get(md.Fields().ByName("map")).Map()
+	_ = m.Get(md.Fields().ByName("bogus")).Int()
+}
+
+func testReassigned(t *proto.Test, fd protoreflect.FieldDescriptor) {
+	m := t.ProtoReflect()
+	i32 := m.Descriptor().Fields().ByName("i32")
+	i32 = fd
+	m.Set(i32, protoreflect.ValueOfString("1"))
+}
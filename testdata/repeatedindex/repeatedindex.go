@@ -0,0 +1,103 @@
+package repeatedindex
+
+import (
+	"github.com/ghostiam/protogetter/testdata/proto"
+)
+
+func testInvalid(t *proto.Test, i int) {
+	_ = t.GetRepeatedEmbeddeds()[0]                                  // want `index of repeated field t\.GetRepeatedEmbeddeds\(\) is not checked with len\(\), the getter returns an empty slice when the field is not set`
+	_ = t.GetRepeatedEmbeddeds()[i].GetS()                           // want `index of repeated field t\.GetRepeatedEmbeddeds\(\) is not checked with len\(\)`
+	_ = t.GetEmbedded().GetEmbedded() == t.GetRepeatedEmbeddeds()[1] // want `index of repeated field t\.GetRepeatedEmbeddeds\(\) is not checked with len\(\)`
+
+	if len(t.GetRepeatedEmbeddeds()) > 0 {
+		return
+	}
+	_ = t.GetRepeatedEmbeddeds()[0] // want `index of repeated field t\.GetRepeatedEmbeddeds\(\) is not checked with len\(\)`
+
+	if len(t.GetRepeatedEmbeddeds()) != 0 {
+		return
+	}
+	_ = t.GetRepeatedEmbeddeds()[0] // want `index of repeated field t\.GetRepeatedEmbeddeds\(\) is not checked with len\(\)`
+}
+
+func testInvalidDirection(t *proto.Test) {
+	if len(t.GetRepeatedEmbeddeds()) == 5 {
+		return
+	}
+	_ = t.GetRepeatedEmbeddeds()[0] // want `index of repeated field t\.GetRepeatedEmbeddeds\(\) is not checked with len\(\)`
+
+	if len(t.GetRepeatedEmbeddeds()) == 0 {
+		_ = t.GetRepeatedEmbeddeds()[0] // want `index of repeated field t\.GetRepeatedEmbeddeds\(\) is not checked with len\(\)`
+	}
+
+	if len(t.GetRepeatedEmbeddeds()) > 0 {
+		return
+	} else {
+		_ = t.GetRepeatedEmbeddeds()[0] // want `index of repeated field t\.GetRepeatedEmbeddeds\(\) is not checked with len\(\)`
+	}
+
+	_ = len(t.GetRepeatedEmbeddeds()) == 0 && t.GetRepeatedEmbeddeds()[0] != nil // want `index of repeated field t\.GetRepeatedEmbeddeds\(\) is not checked with len\(\)`
+	_ = len(t.GetRepeatedEmbeddeds()) > 0 || t.GetRepeatedEmbeddeds()[0] != nil  // want `index of repeated field t\.GetRepeatedEmbeddeds\(\) is not checked with len\(\)`
+}
+
+func testInvalidFix(t *proto.Test) {
+	_ = t.RepeatedEmbeddeds[0].S // want `avoid direct access to proto field t\.RepeatedEmbeddeds\[0\]\.S, use t\.GetRepeatedEmbeddeds\(\)\[0\]\.GetS\(\) instead \(still unsafe: the index is not checked with len\(\)\)`
+}
+
+func testValidFix(t *proto.Test) {
+	if len(t.RepeatedEmbeddeds) > 0 { // want `avoid direct access to proto field t\.RepeatedEmbeddeds, use t\.GetRepeatedEmbeddeds\(\) instead`
+		_ = t.RepeatedEmbeddeds[0].S // want `avoid direct access to proto field t\.RepeatedEmbeddeds\[0\]\.S, use t\.GetRepeatedEmbeddeds\(\)\[0\]\.GetS\(\) instead`
+	}
+}
+
+func testValid(t *proto.Test, i int) {
+	if len(t.GetRepeatedEmbeddeds()) > 0 {
+		_ = t.GetRepeatedEmbeddeds()[0]
+	}
+
+	if i < len(t.GetRepeatedEmbeddeds()) && t.GetRepeatedEmbeddeds()[i].GetS() != "" {
+		return
+	}
+
+	for i := 0; i < len(t.GetRepeatedEmbeddeds()); i++ {
+		_ = t.GetRepeatedEmbeddeds()[i]
+	}
+
+	for i := range t.GetRepeatedEmbeddeds() {
+		_ = t.GetRepeatedEmbeddeds()[i]
+	}
+
+	switch {
+	case len(t.GetRepeatedEmbeddeds()) > 1:
+		_ = t.GetRepeatedEmbeddeds()[1]
+	}
+
+	_ = t.GetB()[0]
+}
+
+func testValidEarlyReturn(t *proto.Test) {
+	if len(t.GetRepeatedEmbeddeds()) == 0 {
+		return
+	}
+
+	_ = t.GetRepeatedEmbeddeds()[0]
+}
+
+func testValidDirection(t *proto.Test) {
+	if len(t.GetRepeatedEmbeddeds()) == 0 {
+		return
+	} else {
+		_ = t.GetRepeatedEmbeddeds()[0]
+	}
+
+	if 0 != len(t.GetRepeatedEmbeddeds()) {
+		_ = t.GetRepeatedEmbeddeds()[0]
+	}
+
+	_ = len(t.GetRepeatedEmbeddeds()) == 0 || t.GetRepeatedEmbeddeds()[0] != nil
+
+	if len(t.GetRepeatedEmbeddeds()) < 2 {
+		return
+	}
+	_ = t.GetRepeatedEmbeddeds()[1]
+}
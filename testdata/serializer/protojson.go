@@ -0,0 +1,11 @@
+package serializer
+
+import (
+	"encoding/json"
+
+	"github.com/ghostiam/protogetter/testdata/proto"
+)
+
+func testOnlyUse(t *proto.Test) ([]byte, error) {
+	return json.Marshal(t) // want `avoid using json\.Marshal with proto message t, use protojson\.Marshal instead`
+}
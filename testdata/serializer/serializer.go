@@ -0,0 +1,50 @@
+package serializer
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"encoding/xml"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/ghostiam/protogetter/testdata/proto"
+)
+
+type wrapper struct {
+	*proto.Test
+	Extra string
+}
+
+type plain struct {
+	Test  *proto.Test
+	Extra string
+}
+
+func testInvalid(t *proto.Test, data []byte) {
+	_, _ = json.Marshal(t)                // want `avoid using json\.Marshal with proto message t, use protojson\.Marshal instead`
+	_ = json.Unmarshal(data, t)           // want `avoid using json\.Unmarshal with proto message t, use protojson\.Unmarshal instead`
+	_, _ = json.MarshalIndent(t, "", " ") // want `avoid using json\.MarshalIndent with proto message t, use protojson\.MarshalOptions instead`
+	_, _ = json.Marshal(t.GetEmbedded())  // want `avoid using json\.Marshal with proto message t\.GetEmbedded\(\), use protojson\.Marshal instead`
+
+	var buf bytes.Buffer
+	_ = json.NewEncoder(&buf).Encode(t) // want `avoid using \(\*json\.Encoder\)\.Encode with proto message t, use protojson\.Marshal instead`
+	_ = gob.NewEncoder(&buf).Encode(t)  // want `avoid using \(\*gob\.Encoder\)\.Encode with proto message t, use proto\.Marshal instead`
+	_ = gob.NewDecoder(&buf).Decode(t)  // want `avoid using \(\*gob\.Decoder\)\.Decode with proto message t, use proto\.Unmarshal instead`
+
+	_, _ = xml.Marshal(t)       // want `avoid using xml\.Marshal with proto message t, use prototext\.Marshal instead`
+	_ = xml.Unmarshal(data, t)  // want `avoid using xml\.Unmarshal with proto message t, use prototext\.Unmarshal instead`
+	_, _ = yaml.Marshal(t)      // want `avoid using yaml\.Marshal with proto message t, use protojson\.Marshal instead`
+	_ = yaml.Unmarshal(data, t) // want `avoid using yaml\.Unmarshal with proto message t, use protojson\.Unmarshal instead`
+
+	w := &wrapper{Test: t, Extra: "extra"}
+	_, _ = json.Marshal(w)       // want `avoid using json\.Marshal with w, it embeds proto message Test, use protojson\.Marshal instead`
+	_ = yaml.Unmarshal(data, &w) // want `avoid using yaml\.Unmarshal with &w, it embeds proto message Test, use protojson\.Unmarshal instead`
+}
+
+func testValid(t *proto.Test, data []byte) {
+	_, _ = json.Marshal(t.GetS())
+	_, _ = json.Marshal(map[string]string{"s": t.GetS()})
+	_, _ = json.Marshal(plain{Test: t})
+	_ = json.Unmarshal(data, &struct{ S string }{})
+}
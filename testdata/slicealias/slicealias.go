@@ -0,0 +1,69 @@
+package slicealias
+
+import (
+	"slices"
+	"sort"
+
+	"github.com/ghostiam/protogetter/testdata/proto"
+)
+
+func testInvalidMutation(t *proto.Test, e *proto.Embedded) {
+	s := t.GetRepeatedEmbeddeds()
+	s[0] = e                                            // want `s\[0\] modifies the backing array of repeated field t\.GetRepeatedEmbeddeds\(\) through alias s created at line 11`
+	sort.Slice(s, func(i, j int) bool { return i < j }) // want `sort\.Slice modifies the backing array of repeated field t\.GetRepeatedEmbeddeds\(\) through alias s created at line 11`
+
+	var tail = t.GetRepeatedEmbeddeds()[1:]
+	copy(tail, []*proto.Embedded{e}) // want `copy modifies the backing array of repeated field t\.GetRepeatedEmbeddeds\(\)\[1:\] through alias tail created at line 15`
+}
+
+func testInvalidStore(t, other *proto.Test, e *proto.Embedded) {
+	other.RepeatedEmbeddeds = t.GetRepeatedEmbeddeds()            // want `repeated field t\.GetRepeatedEmbeddeds\(\) is stored into other\.RepeatedEmbeddeds without a copy, both messages share the backing array`
+	other.RepeatedEmbeddeds = append(t.GetRepeatedEmbeddeds(), e) // want `append to repeated field t\.GetRepeatedEmbeddeds\(\) is stored into other\.RepeatedEmbeddeds, it may write into the backing array of t\.GetRepeatedEmbeddeds\(\)`
+
+	items := t.GetRepeatedEmbeddeds()
+	other.RepeatedEmbeddeds = items // want `repeated field t\.GetRepeatedEmbeddeds\(\) is stored into other\.RepeatedEmbeddeds without a copy, both messages share the backing array \(alias items created at line 23\)`
+
+	_ = &proto.Test{
+		RepeatedEmbeddeds: t.GetRepeatedEmbeddeds(), // want `repeated field t\.GetRepeatedEmbeddeds\(\) is stored into RepeatedEmbeddeds without a copy, both messages share the backing array`
+	}
+}
+
+func testValid(t, other *proto.Test, e *proto.Embedded) {
+	t.RepeatedEmbeddeds = append(t.GetRepeatedEmbeddeds(), e)
+
+	s := t.GetRepeatedEmbeddeds()
+	for _, item := range s {
+		_ = item.GetS()
+	}
+
+	other.RepeatedEmbeddeds = append([]*proto.Embedded(nil), t.GetRepeatedEmbeddeds()...)
+
+	local := []*proto.Embedded{e}
+	local[0] = e
+	other.RepeatedEmbeddeds = local
+
+	_ = t.GetB()
+}
+
+func testValidReassigned(t, other *proto.Test, e *proto.Embedded) {
+	s := t.GetRepeatedEmbeddeds()
+	s = slices.Clone(s)
+	s[0] = e
+	other.RepeatedEmbeddeds = s
+
+	var items = t.GetRepeatedEmbeddeds()
+	items = nil
+	other.RepeatedEmbeddeds = items
+}
+
+func testInvalidResliced(t *proto.Test, e *proto.Embedded) {
+	s := t.GetRepeatedEmbeddeds()
+	s = s[1:]
+	s[0] = e // want `s\[0\] modifies the backing array of repeated field t\.GetRepeatedEmbeddeds\(\) through alias s created at line 60`
+
+	s = slices.Clone(s)
+	s[0] = e
+
+	s = t.GetRepeatedEmbeddeds()
+	s[0] = e // want `s\[0\] modifies the backing array of repeated field t\.GetRepeatedEmbeddeds\(\) through alias s created at line 67`
+}
@@ -0,0 +1,56 @@
+package testdata
+
+import (
+	"github.com/ghostiam/protogetter/testdata/proto"
+)
+
+func testInvalidOneof(t *proto.Foo, many []*proto.Foo) {
+	_ = t.Bar.(*proto.Foo_Baz).Baz                      // want `avoid direct access to proto field t\.Bar\.\(\*proto\.Foo_Baz\)\.Baz, use t\.GetBaz\(\) instead`
+	_ = t.GetBar().(*proto.Foo_Baz).Baz                 // want `avoid direct access to proto field t\.GetBar\(\)\.\(\*proto\.Foo_Baz\)\.Baz, use t\.GetBaz\(\) instead`
+	_ = t.GetBar().(*proto.Foo_Quix).Quix == ""         // want `avoid direct access to proto field t\.GetBar\(\)\.\(\*proto\.Foo_Quix\)\.Quix, use t\.GetQuix\(\) instead`
+	_ = many[0].GetBar().(*proto.Foo_Quix).Quix         // want `avoid direct access to proto field many\[0\]\.GetBar\(\)\.\(\*proto\.Foo_Quix\)\.Quix, use many\[0\]\.GetQuix\(\) instead`
+	func(...interface{}) {}(t.Bar.(*proto.Foo_Baz).Baz) // want `avoid direct access to proto field t\.Bar\.\(\*proto\.Foo_Baz\)\.Baz, use t\.GetBaz\(\) instead`
+
+	baz, _ := t.GetBar().(*proto.Foo_Baz)
+	_ = baz.Baz // want `avoid direct access to proto field baz\.Baz, use t\.GetBaz\(\) instead`
+
+	quix, ok := t.Bar.(*proto.Foo_Quix) // want `avoid direct access to proto field t\.Bar, use t\.GetBar\(\) instead`
+	_ = quix.Quix                       // want `avoid direct access to proto field quix\.Quix, use t\.GetQuix\(\) instead`
+	_ = ok
+
+	// The variable is used elsewhere, so the read alone can be fixed.
+	other, _ := t.GetBar().(*proto.Foo_Baz)
+	_ = other.Baz // want `avoid direct access to proto field other\.Baz, use t\.GetBaz\(\) instead`
+	_ = other
+}
+
+func testValidOneof(t *proto.Foo) {
+	_ = t.GetBaz()
+	_ = t.GetQuix()
+
+	switch v := t.GetBar().(type) {
+	case *proto.Foo_Baz:
+		_ = v.Baz
+	case *proto.Foo_Quix:
+		_ = v.Quix
+	}
+
+	if v, ok := t.GetBar().(*proto.Foo_Baz); ok {
+		_ = v.Baz
+	}
+
+	v, ok := t.GetBar().(*proto.Foo_Quix)
+	if !ok {
+		return
+	}
+	_ = v.Quix
+
+	w, _ := t.GetBar().(*proto.Foo_Baz)
+	if w != nil {
+		_ = w.Baz
+	}
+	w.Baz = "x"
+
+	var wrapper proto.Foo_Baz
+	_ = wrapper.Baz
+}
@@ -0,0 +1,104 @@
+package wkt
+
+import (
+	"errors"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	pb "github.com/ghostiam/protogetter/testdata/proto"
+)
+
+func testTimestamp(ts *timestamppb.Timestamp) time.Time {
+	return time.Unix(ts.Seconds, int64(ts.Nanos)) // want `avoid converting ts manually, use ts\.AsTime\(\)\.Local\(\) instead`
+}
+
+func testTimestampGetters(ts *timestamppb.Timestamp) time.Time {
+	return time.Unix(ts.GetSeconds(), int64(ts.GetNanos())) // want `avoid converting ts manually, use ts\.AsTime\(\)\.Local\(\) instead`
+}
+
+func testTimestampSecondsOnly(ts *timestamppb.Timestamp) time.Time {
+	return time.Unix(ts.GetSeconds(), 0)
+}
+
+func testTimestampDifferent(a, b *timestamppb.Timestamp) time.Time {
+	return time.Unix(a.GetSeconds(), int64(b.GetNanos()))
+}
+
+func testDuration(d *durationpb.Duration) int64 {
+	return d.GetSeconds() * 1e9 // want `avoid converting d manually, use int64\(d\.AsDuration\(\)\) instead`
+}
+
+func testDurationUnit(d *durationpb.Duration) time.Duration {
+	return time.Duration(d.GetSeconds()) * time.Second // want `avoid converting d manually, use d\.AsDuration\(\) instead`
+}
+
+func testDurationSum(d *durationpb.Duration) time.Duration {
+	return time.Duration(d.Seconds)*time.Second + time.Duration(d.Nanos) // want `avoid converting d manually, use d\.AsDuration\(\) instead`
+}
+
+func testDurationMillis(d *durationpb.Duration) int64 {
+	return d.GetSeconds() * 1000
+}
+
+func testWrappers(t *pb.Test) (*wrapperspb.StringValue, *wrapperspb.BoolValue, *wrapperspb.UInt64Value) {
+	s := &wrapperspb.StringValue{Value: t.GetS()} // want `avoid creating wrapperspb\.StringValue manually, use wrapperspb\.String\(t\.GetS\(\)\) instead`
+	b := &wrapperspb.BoolValue{Value: true}       // want `avoid creating wrapperspb\.BoolValue manually, use wrapperspb\.Bool\(true\) instead`
+	u := &wrapperspb.UInt64Value{}
+	return s, b, u
+}
+
+func testNewTimestamp(now time.Time) *timestamppb.Timestamp {
+	return &timestamppb.Timestamp{Seconds: now.Unix(), Nanos: int32(now.Nanosecond())} // want `avoid creating timestamppb\.Timestamp manually, use timestamppb\.New\(now\) instead`
+}
+
+func testNewTimestampSeconds(now time.Time) *timestamppb.Timestamp {
+	return &timestamppb.Timestamp{Seconds: now.Unix()}
+}
+
+func testStruct(s *structpb.Struct, l *structpb.ListValue) []string {
+	var keys []string
+	for k := range s.GetFields() { // want `avoid walking s\.GetFields\(\) manually, use s\.AsMap\(\) instead`
+		keys = append(keys, k)
+	}
+
+	for _, v := range l.GetValues() { // want `avoid walking l\.GetValues\(\) manually, use l\.AsSlice\(\) instead`
+		keys = append(keys, v.GetStringValue())
+	}
+
+	return keys
+}
+
+func testValidate(ts *timestamppb.Timestamp) error {
+	if ts.GetNanos() < 0 || ts.GetNanos() >= 1e9 { // want `avoid validating ts manually, use ts\.CheckValid\(\) instead`
+		return errors.New("invalid timestamp")
+	}
+
+	return nil
+}
+
+func testValidateOther(ts *timestamppb.Timestamp, d *durationpb.Duration) bool {
+	if 999999999 < d.GetNanos() { // want `avoid validating d manually, use d\.CheckValid\(\) instead`
+		return false
+	}
+
+	return d.GetSeconds() > 60 || ts.GetSeconds() < 0 || ts.GetNanos() > 500
+}
+
+func testAny(a *anypb.Any) (*pb.Test, error) {
+	m := new(pb.Test)
+	if err := proto.Unmarshal(a.Value, m); err != nil { // want `avoid unmarshaling a\.Value manually, use a\.UnmarshalTo\(m\) instead`
+		return nil, err
+	}
+
+	return m, nil
+}
+
+func testValid(ts *timestamppb.Timestamp, d *durationpb.Duration, a *anypb.Any, m *pb.Test) (time.Time, time.Duration, error) {
+	return ts.AsTime(), d.AsDuration(), a.UnmarshalTo(m)
+}
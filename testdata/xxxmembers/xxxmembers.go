@@ -0,0 +1,56 @@
+package xxxmembers
+
+import (
+	"github.com/ghostiam/protogetter/testdata/proto"
+)
+
+// Old is a message as generated by old protoc-gen-go versions.
+type Old struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Old) Reset()         { *m = Old{} }
+func (m *Old) String() string { return "" }
+func (*Old) ProtoMessage()    {}
+
+func (m *Old) XXX_Unmarshal(b []byte) error {
+	return nil
+}
+
+func (m *Old) XXX_Size() int {
+	return 0
+}
+
+func (m *Old) XXX_DiscardUnknown() {}
+
+// plain is not a message, its XXX_ members are fine.
+type plain struct {
+	XXX_unrecognized []byte
+}
+
+func testInvalid(m *Old, t *proto.Test) {
+	_ = m.XXX_unrecognized   // want `avoid m\.XXX_unrecognized of proto message Old, XXX_ members are internal to old generated code, use ProtoReflect\(\)\.GetUnknown\(\) instead`
+	m.XXX_unrecognized = nil // want `avoid m\.XXX_unrecognized of proto message Old, XXX_ members are internal to old generated code, use ProtoReflect\(\)\.GetUnknown\(\) instead`
+	_ = m.XXX_sizecache      // want `avoid m\.XXX_sizecache of proto message Old, XXX_ members are internal to old generated code, use proto\.Size instead`
+	_ = m.XXX_Size()         // want `avoid m\.XXX_Size of proto message Old, XXX_ members are internal to old generated code, use proto\.Size instead`
+	_ = m.XXX_Unmarshal(nil) // want `avoid m\.XXX_Unmarshal of proto message Old, XXX_ members are internal to old generated code, use proto\.Unmarshal instead`
+	m.XXX_DiscardUnknown()   // want `avoid m\.XXX_DiscardUnknown of proto message Old, XXX_ members are internal to old generated code, use ProtoReflect\(\)\.SetUnknown\(nil\) instead`
+
+	size := m.XXX_Size // want `avoid m\.XXX_Size of proto message Old`
+	_ = size
+
+	_ = &Old{
+		XXX_NoUnkeyedLiteral: struct{}{}, // want `avoid XXX_NoUnkeyedLiteral of proto message Old, XXX_ members are internal to old generated code and go away on regeneration`
+		XXX_unrecognized:     []byte{1},  // want `avoid XXX_unrecognized of proto message Old, XXX_ members are internal to old generated code, use ProtoReflect\(\)\.GetUnknown\(\) instead`
+	}
+}
+
+func testValid(m *Old, t *proto.Test, p *plain) {
+	_ = m.String()
+	_ = t.ProtoReflect()
+	_ = p.XXX_unrecognized
+	_ = &plain{XXX_unrecognized: nil}
+	_ = t.GetS()
+}
@@ -0,0 +1,466 @@
+package protogetter
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+const wellKnownMsgFormat = "avoid %s %s manually, use %s instead"
+
+const (
+	wrapperspbPkgPath = "google.golang.org/protobuf/types/known/wrapperspb"
+	structpbPkgPath   = "google.golang.org/protobuf/types/known/structpb"
+)
+
+// wellKnownRangeHelpers are the helpers converting the well-known type fields walked by hand.
+var wellKnownRangeHelpers = []struct {
+	wkt, field, helper string
+}{
+	{wkt: "structpb.Struct", field: "Fields", helper: "AsMap"},
+	{wkt: "structpb.ListValue", field: "Values", helper: "AsSlice"},
+}
+
+// checkWellKnownTypes reports manual conversions of the well-known types which have helpers:
+// `time.Unix(ts.GetSeconds(), int64(ts.GetNanos()))` instead of `ts.AsTime()`,
+// `d.GetSeconds()*1e9` instead of `d.AsDuration()`, wrapper and timestamp literals instead of the constructors,
+// walking `structpb.Struct` fields instead of `AsMap()`, validating by hand instead of `CheckValid()`
+// and unmarshaling `Any` values instead of `UnmarshalTo()`.
+//
+// It runs before the getter check and filters the field reads it rewrites,
+// so the getter fix doesn't conflict with the helper fix.
+func checkWellKnownTypes(pass *analysis.Pass, ins *inspector.Inspector, filter *PosFilter) {
+	nodeTypes := []ast.Node{
+		(*ast.CallExpr)(nil),
+		(*ast.BinaryExpr)(nil),
+		(*ast.UnaryExpr)(nil),
+		(*ast.RangeStmt)(nil),
+	}
+
+	// validated dedupes the validation reports of the same message in a statement.
+	validated := make(map[string]struct{})
+
+	ins.WithStack(nodeTypes, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+
+		switch x := n.(type) {
+		case *ast.CallExpr:
+			checkWellKnownCall(pass, filter, x)
+
+		case *ast.BinaryExpr:
+			switch x.Op {
+			case token.ADD, token.MUL:
+				if x.Op == token.MUL && len(stack) > 1 {
+					// `d.GetSeconds()*1e9 + d.GetNanos()` is reported as a whole.
+					if parent, ok := stack[len(stack)-2].(*ast.BinaryExpr); ok {
+						if _, ok := durationSum(pass.TypesInfo, parent); ok {
+							return true
+						}
+					}
+				}
+
+				checkDurationConversion(pass, filter, x)
+
+			case token.LSS, token.GTR, token.LEQ, token.GEQ:
+				owner, ok := manualValidation(pass.TypesInfo, x)
+				if !ok {
+					return true
+				}
+
+				key := fmt.Sprintf("%d:%s", enclosingStmt(stack).Pos(), accessPath(pass.TypesInfo, owner))
+				if _, ok := validated[key]; ok {
+					return true
+				}
+				validated[key] = struct{}{}
+
+				pass.Report(analysis.Diagnostic{
+					Pos:      x.Pos(),
+					End:      x.End(),
+					Category: "well-known-types",
+					Message:  fmt.Sprintf(wellKnownMsgFormat, "validating", formatNode(owner), formatNode(owner)+".CheckValid()"),
+				})
+			}
+
+		case *ast.UnaryExpr:
+			if x.Op == token.AND {
+				checkWellKnownLiteral(pass, x)
+			}
+
+		case *ast.RangeStmt:
+			for _, h := range wellKnownRangeHelpers {
+				owner, ok := wellKnownFieldRead(pass.TypesInfo, x.X, h.wkt, h.field)
+				if !ok {
+					continue
+				}
+
+				pass.Report(analysis.Diagnostic{
+					Pos:      x.X.Pos(),
+					End:      x.X.End(),
+					Category: "well-known-types",
+					Message:  fmt.Sprintf(wellKnownMsgFormat, "walking", formatNode(x.X), formatNode(owner)+"."+h.helper+"()"),
+				})
+			}
+		}
+
+		return true
+	})
+}
+
+// checkWellKnownCall reports `time.Unix(ts.GetSeconds(), int64(ts.GetNanos()))`
+// and `proto.Unmarshal(a.GetValue(), m)`.
+func checkWellKnownCall(pass *analysis.Pass, filter *PosFilter, call *ast.CallExpr) {
+	fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+	if !ok || fn.Pkg() == nil || len(call.Args) != 2 {
+		return
+	}
+
+	switch {
+	case fn.Pkg().Path() == "time" && fn.Name() == "Unix":
+		seconds, ok := wellKnownFieldRead(pass.TypesInfo, call.Args[0], "timestamppb.Timestamp", "Seconds")
+		if !ok {
+			return
+		}
+
+		nanos, ok := wellKnownFieldRead(pass.TypesInfo, call.Args[1], "timestamppb.Timestamp", "Nanos")
+		if !ok || accessPath(pass.TypesInfo, seconds) != accessPath(pass.TypesInfo, nanos) {
+			return
+		}
+
+		// time.Unix returns the local time, AsTime returns UTC.
+		reportWellKnownFix(pass, filter, call, "converting", formatNode(seconds), formatNode(seconds)+".AsTime().Local()", call.Args...)
+
+	case (fn.Pkg().Path() == protoPkgPath || fn.Pkg().Path() == "github.com/golang/protobuf/proto") && fn.Name() == "Unmarshal":
+		owner, ok := wellKnownFieldRead(pass.TypesInfo, call.Args[0], "anypb.Any", "Value")
+		if !ok {
+			return
+		}
+
+		replacement := fmt.Sprintf("%s.UnmarshalTo(%s)", formatNode(owner), formatNode(call.Args[1]))
+		if hasLegacyMessageArg(pass.TypesInfo, call) {
+			// UnmarshalTo accepts only the google.golang.org/protobuf messages.
+			pass.Report(analysis.Diagnostic{
+				Pos:      call.Pos(),
+				End:      call.End(),
+				Category: "well-known-types",
+				Message:  fmt.Sprintf(wellKnownMsgFormat, "unmarshaling", formatNode(call.Args[0]), replacement),
+			})
+			return
+		}
+
+		reportWellKnownFix(pass, filter, call, "unmarshaling", formatNode(call.Args[0]), replacement, call.Args[0])
+	}
+}
+
+// checkDurationConversion reports `d.GetSeconds()*1e9`, `time.Duration(d.GetSeconds())*time.Second`
+// and their sums with the nanos.
+func checkDurationConversion(pass *analysis.Pass, filter *PosFilter, expr *ast.BinaryExpr) {
+	var (
+		owner ast.Expr
+		ok    bool
+	)
+
+	if expr.Op == token.ADD {
+		owner, ok = durationSum(pass.TypesInfo, expr)
+	} else {
+		owner, ok = durationSeconds(pass.TypesInfo, expr)
+	}
+
+	if !ok {
+		return
+	}
+
+	replacement := formatNode(owner) + ".AsDuration()"
+	what := formatNode(owner)
+
+	// Unlike the sum, the seconds alone leave the nanos out, the fix would change the value.
+	fixable := expr.Op == token.ADD
+
+	t := pass.TypesInfo.TypeOf(expr)
+	if named, ok := t.(*types.Named); ok && named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == "time" && named.Obj().Name() == "Duration" {
+		if fixable {
+			reportWellKnownFix(pass, filter, expr, "converting", what, replacement, expr)
+			return
+		}
+	} else if basic, ok := t.(*types.Basic); ok && basic.Info()&types.IsNumeric != 0 {
+		replacement = fmt.Sprintf("%s(%s)", basic.Name(), replacement)
+		if fixable {
+			reportWellKnownFix(pass, filter, expr, "converting", what, replacement, expr)
+			return
+		}
+	}
+
+	pass.Report(analysis.Diagnostic{
+		Pos:      expr.Pos(),
+		End:      expr.End(),
+		Category: "well-known-types",
+		Message:  fmt.Sprintf(wellKnownMsgFormat, "converting", what, replacement),
+	})
+}
+
+// durationSum checks that the expression is `d.GetSeconds()*1e9 + d.GetNanos()`, in any order
+// and with any conversions, and returns the duration.
+func durationSum(info *types.Info, expr *ast.BinaryExpr) (ast.Expr, bool) {
+	if expr.Op != token.ADD {
+		return nil, false
+	}
+
+	for _, pair := range [][2]ast.Expr{{expr.X, expr.Y}, {expr.Y, expr.X}} {
+		mul, ok := ast.Unparen(pair[0]).(*ast.BinaryExpr)
+		if !ok {
+			continue
+		}
+
+		seconds, ok := durationSeconds(info, mul)
+		if !ok {
+			continue
+		}
+
+		nanos, ok := wellKnownFieldRead(info, pair[1], "durationpb.Duration", "Nanos")
+		if ok && accessPath(info, seconds) == accessPath(info, nanos) {
+			return seconds, true
+		}
+	}
+
+	return nil, false
+}
+
+// durationSeconds checks that the expression is `d.GetSeconds()*1e9` or `time.Duration(d.GetSeconds())*time.Second`,
+// in any order, and returns the duration.
+func durationSeconds(info *types.Info, expr *ast.BinaryExpr) (ast.Expr, bool) {
+	if expr.Op != token.MUL {
+		return nil, false
+	}
+
+	for _, pair := range [][2]ast.Expr{{expr.X, expr.Y}, {expr.Y, expr.X}} {
+		owner, ok := wellKnownFieldRead(info, pair[0], "durationpb.Duration", "Seconds")
+		if !ok {
+			continue
+		}
+
+		tv, ok := info.Types[pair[1]]
+		if ok && tv.Value != nil && constant.Compare(constant.ToInt(tv.Value), token.EQL, constant.MakeInt64(1e9)) {
+			return owner, true
+		}
+	}
+
+	return nil, false
+}
+
+// manualValidation checks that the expression is a range check of the nanos of a timestamp or a duration,
+// like `ts.GetNanos() < 0` or `ts.GetNanos() >= 1e9`, and returns the message.
+func manualValidation(info *types.Info, expr *ast.BinaryExpr) (ast.Expr, bool) {
+	for i, pair := range [][2]ast.Expr{{expr.X, expr.Y}, {expr.Y, expr.X}} {
+		tv, ok := info.Types[pair[1]]
+		if !ok || tv.Value == nil {
+			continue
+		}
+
+		op := expr.Op
+		if i == 1 {
+			op = mirroredComparison(op)
+		}
+
+		value := constant.ToInt(tv.Value)
+		isRangeCheck := (op == token.LSS && constant.Sign(value) == 0) ||
+			(op == token.GEQ && constant.Compare(value, token.EQL, constant.MakeInt64(1e9))) ||
+			(op == token.GTR && constant.Compare(value, token.EQL, constant.MakeInt64(999999999)))
+		if !isRangeCheck {
+			continue
+		}
+
+		for _, wkt := range []string{"timestamppb.Timestamp", "durationpb.Duration"} {
+			if owner, ok := wellKnownFieldRead(info, pair[0], wkt, "Nanos"); ok {
+				return owner, true
+			}
+		}
+	}
+
+	return nil, false
+}
+
+// mirroredComparison returns the operator comparing the operands in the other order, like `>` for `<`.
+func mirroredComparison(op token.Token) token.Token {
+	switch op {
+	case token.LSS:
+		return token.GTR
+	case token.LEQ:
+		return token.GEQ
+	case token.GTR:
+		return token.LSS
+	case token.GEQ:
+		return token.LEQ
+	}
+
+	return op
+}
+
+// checkWellKnownLiteral reports `&wrapperspb.StringValue{Value: s}` instead of `wrapperspb.String(s)`
+// and `&timestamppb.Timestamp{Seconds: t.Unix(), Nanos: int32(t.Nanosecond())}` instead of `timestamppb.New(t)`.
+func checkWellKnownLiteral(pass *analysis.Pass, expr *ast.UnaryExpr) {
+	lit, ok := expr.X.(*ast.CompositeLit)
+	if !ok {
+		return
+	}
+
+	typ, ok := lit.Type.(*ast.SelectorExpr)
+	if !ok {
+		return
+	}
+
+	values := make(map[string]ast.Expr)
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			return
+		}
+
+		key, ok := kv.Key.(*ast.Ident)
+		if !ok {
+			return
+		}
+		values[key.Name] = kv.Value
+	}
+
+	var replacement string
+	switch wkt := wellKnownType(pass.TypesInfo.TypeOf(lit)); {
+	case strings.HasPrefix(wkt, "wrapperspb.") && len(values) == 1 && values["Value"] != nil:
+		replacement = fmt.Sprintf("%s.%s(%s)", formatNode(typ.X), strings.TrimSuffix(typ.Sel.Name, "Value"), formatNode(values["Value"]))
+
+	case wkt == "timestamppb.Timestamp" && len(values) == 2:
+		seconds, ok := timeMethodCall(pass.TypesInfo, values["Seconds"], "Unix")
+		if !ok {
+			return
+		}
+
+		nanos, ok := timeMethodCall(pass.TypesInfo, values["Nanos"], "Nanosecond")
+		if !ok || formatNode(seconds) != formatNode(nanos) || !isPureExpr(seconds) {
+			return
+		}
+		replacement = fmt.Sprintf("%s.New(%s)", formatNode(typ.X), formatNode(seconds))
+
+	default:
+		return
+	}
+
+	reportWellKnownFix(pass, nil, expr, "creating", formatNode(lit.Type), replacement)
+}
+
+// timeMethodCall checks that the expression is a call of the time.Time method without arguments,
+// possibly converted, like `int32(t.Nanosecond())`, and returns the time.
+func timeMethodCall(info *types.Info, expr ast.Expr, method string) (ast.Expr, bool) {
+	if expr == nil {
+		return nil, false
+	}
+
+	call, ok := unconvert(info, expr).(*ast.CallExpr)
+	if !ok || len(call.Args) != 0 {
+		return nil, false
+	}
+
+	fn, ok := typeutil.Callee(info, call).(*types.Func)
+	if !ok || fn.Name() != method || fn.Pkg() == nil || fn.Pkg().Path() != "time" {
+		return nil, false
+	}
+
+	return call.Fun.(*ast.SelectorExpr).X, true
+}
+
+// reportWellKnownFix reports the node replaced with the helper and filters the nodes
+// the getter check would otherwise rewrite in the removed expressions.
+func reportWellKnownFix(pass *analysis.Pass, filter *PosFilter, node ast.Node, verb, what, replacement string, removed ...ast.Expr) {
+	if filter != nil {
+		for _, expr := range removed {
+			ast.Inspect(expr, func(n ast.Node) bool {
+				if n != nil {
+					filter.AddPos(n.Pos())
+				}
+				return true
+			})
+		}
+	}
+
+	pass.Report(analysis.Diagnostic{
+		Pos:      node.Pos(),
+		End:      node.End(),
+		Category: "well-known-types",
+		Message:  fmt.Sprintf(wellKnownMsgFormat, verb, what, replacement),
+		SuggestedFixes: []analysis.SuggestedFix{
+			{
+				Message: "use " + replacement,
+				TextEdits: []analysis.TextEdit{
+					{
+						Pos:     node.Pos(),
+						End:     node.End(),
+						NewText: []byte(replacement),
+					},
+				},
+			},
+		},
+	})
+}
+
+// wellKnownFieldRead checks that the expression reads the field of the well-known type,
+// `ts.Seconds` or `ts.GetSeconds()`, possibly converted like `int64(ts.GetNanos())`, and returns the message.
+func wellKnownFieldRead(info *types.Info, expr ast.Expr, wkt, field string) (ast.Expr, bool) {
+	var owner ast.Expr
+	switch x := unconvert(info, expr).(type) {
+	case *ast.SelectorExpr:
+		if x.Sel.Name != field {
+			return nil, false
+		}
+		owner = x.X
+
+	case *ast.CallExpr:
+		fun, ok := x.Fun.(*ast.SelectorExpr)
+		if !ok || len(x.Args) != 0 || fun.Sel.Name != "Get"+field {
+			return nil, false
+		}
+		owner = fun.X
+
+	default:
+		return nil, false
+	}
+
+	if wellKnownType(info.TypeOf(owner)) != wkt {
+		return nil, false
+	}
+
+	return owner, true
+}
+
+// unconvert strips the parentheses and the type conversions from the expression.
+func unconvert(info *types.Info, expr ast.Expr) ast.Expr {
+	for {
+		expr = ast.Unparen(expr)
+
+		call, ok := expr.(*ast.CallExpr)
+		if !ok || len(call.Args) != 1 {
+			return expr
+		}
+
+		if tv, ok := info.Types[call.Fun]; !ok || !tv.IsType() {
+			return expr
+		}
+		expr = call.Args[0]
+	}
+}
+
+// enclosingStmt returns the innermost statement of the stack.
+func enclosingStmt(stack []ast.Node) ast.Node {
+	for i := len(stack) - 1; i >= 0; i-- {
+		if stmt, ok := stack[i].(ast.Stmt); ok {
+			return stmt
+		}
+	}
+
+	return stack[0]
+}
@@ -0,0 +1,98 @@
+package protogetter
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+const (
+	xxxMemberMsgFormat              = "avoid %s of proto message %s, XXX_ members are internal to old generated code, use %s instead"
+	xxxMemberNoReplacementMsgFormat = "avoid %s of proto message %s, XXX_ members are internal to old generated code and go away on regeneration"
+)
+
+// xxxReplacements maps the XXX_ fields and methods generated by old protoc-gen-go versions
+// to the google.golang.org/protobuf API replacing them.
+var xxxReplacements = map[string]string{
+	"XXX_unrecognized":       "ProtoReflect().GetUnknown()",
+	"XXX_sizecache":          "proto.Size",
+	"XXX_Size":               "proto.Size",
+	"XXX_Marshal":            "proto.Marshal",
+	"XXX_Unmarshal":          "proto.Unmarshal",
+	"XXX_Merge":              "proto.Merge",
+	"XXX_DiscardUnknown":     "ProtoReflect().SetUnknown(nil)",
+	"XXX_InternalExtensions": "proto.GetExtension and proto.SetExtension",
+	"XXX_extensions":         "proto.GetExtension and proto.SetExtension",
+	"XXX_OneofWrappers":      "ProtoReflect().Descriptor().Oneofs()",
+	"XXX_OneofFuncs":         "ProtoReflect().Descriptor().Oneofs()",
+	"XXX_WellKnownType":      "ProtoReflect().Descriptor().FullName()",
+}
+
+// checkXXXMembers reports the selectors of `XXX_` fields and methods of proto messages,
+// like `m.XXX_unrecognized` or `m.XXX_Size()`, and the `XXX_` keys of message literals.
+func checkXXXMembers(pass *analysis.Pass, ins *inspector.Inspector) {
+	nodeTypes := []ast.Node{
+		(*ast.SelectorExpr)(nil),
+		(*ast.CompositeLit)(nil),
+	}
+
+	ins.Preorder(nodeTypes, func(n ast.Node) {
+		switch x := n.(type) {
+		case *ast.SelectorExpr:
+			if !strings.HasPrefix(x.Sel.Name, "XXX_") {
+				return
+			}
+
+			selection, ok := pass.TypesInfo.Selections[x]
+			if !ok || !isProtoMessageType(selection.Recv()) {
+				return
+			}
+
+			named, ok := namedType(selection.Recv())
+			if !ok {
+				return
+			}
+
+			reportXXXMember(pass, x, formatNode(x), x.Sel.Name, types.TypeString(named, types.RelativeTo(pass.Pkg)))
+
+		case *ast.CompositeLit:
+			t := pass.TypesInfo.TypeOf(x)
+			if !isProtoMessageType(t) {
+				return
+			}
+
+			for _, elt := range x.Elts {
+				kv, ok := elt.(*ast.KeyValueExpr)
+				if !ok {
+					continue
+				}
+
+				key, ok := kv.Key.(*ast.Ident)
+				if !ok || !strings.HasPrefix(key.Name, "XXX_") {
+					continue
+				}
+
+				reportXXXMember(pass, kv, key.Name, key.Name, types.TypeString(t, types.RelativeTo(pass.Pkg)))
+			}
+		}
+	})
+}
+
+// reportXXXMember reports the use of the XXX_ member with the name, msgType is the message type name.
+func reportXXXMember(pass *analysis.Pass, node ast.Node, what, name, msgType string) {
+	text := fmt.Sprintf(xxxMemberNoReplacementMsgFormat, what, msgType)
+	if replacement, ok := xxxReplacements[name]; ok {
+		text = fmt.Sprintf(xxxMemberMsgFormat, what, msgType, replacement)
+	}
+
+	pass.Report(analysis.Diagnostic{
+		Pos:      node.Pos(),
+		End:      node.End(),
+		Category: "xxx-members",
+		Message:  text,
+	})
+}